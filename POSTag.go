@@ -8,25 +8,14 @@ import (
 // POSTag represents a Part of Speech Tag.
 type POSTag byte
 
-var posTagLookup map[string]POSTag
-
-func init() {
-	posTagLookup = make(map[string]POSTag)
-	for t := X; t < MAXTAG; t++ {
-		s := t.String()
-		posTagLookup[s] = POSTag(t)
-		posTagLookup[strings.ToLower(s)] = POSTag(t)
-	}
-}
-
 func (p POSTag) MarshalText() ([]byte, error) {
 	return []byte(fmt.Sprintf("%v", p)), nil // add quotes back
 }
 
 func (p *POSTag) UnmarshalText(text []byte) error {
 	str := strings.Trim(string(text), `"`) // for JSON use, if any
-	tag, _ := posTagLookup[str]
-	*p = tag
+	tag, _ := UniversalTags.Tag(str)
+	*p = tag.POSTag()
 	return nil
 }
 
@@ -1,9 +1,8 @@
-// +build !stanfordtags
-
 package lingua
 
 //go:generate stringer -type=POSTag -output=POSTag_universal_string.go
 
+// BUILD_TAGSET is the tagset of the POSTag constants. Other tagsets are used through a TagSet (e.g. PennTags).
 const BUILD_TAGSET = "universaltags"
 
 const (
@@ -1,5 +1,3 @@
-// +build !stanfordtags
-
 // Code generated by "stringer -type=POSTag -output=POSTag_universal_string.go"; DO NOT EDIT
 
 package lingua
@@ -24,9 +24,10 @@ type Annotation struct {
 	children AnnotationSet //will not be serialized
 
 	// info about the annotation itself
-	Lemma   string
-	Lowered string
-	Stem    string
+	Lemma    string
+	Lowered  string
+	Stem     string
+	Features Features
 
 	// auxiliary data for processing
 	Cluster
@@ -111,12 +112,22 @@ func (a *Annotation) Process(f AnnotationFixer) error {
 		if f != nil {
 			var stem string
 			if stem, err = f.Stem(a.Lowered); err != nil {
-				if _, ok := err.(componentUnavailable); !ok {
+				if !isUnavailable(err) {
 					return err
 				}
 			}
 			a.Stem = stem
 
+			var lemmas []string
+			if lemmas, err = f.Lemmatize(a.Lowered, a.POSTag); err != nil {
+				if !isUnavailable(err) {
+					return err
+				}
+			}
+			if len(lemmas) > 0 {
+				a.Lemma = lemmas[0]
+			}
+
 			var clust map[string]Cluster
 			if clust, err = f.Clusters(); err == nil {
 				a.Cluster = clust[a.Value]
@@ -23,7 +23,12 @@ func mediumSentence() []treebank.SentenceTag {
 
 `
 	readr := strings.NewReader(conllu)
-	return treebank.ReadConllu(readr)
+	// the last line has a trailing tab, which only the lenient reader accepts
+	sentences, err := treebank.ReadConllu(readr, treebank.Lenient(nil))
+	if err != nil {
+		panic(err)
+	}
+	return sentences
 }
 
 const EPSILON64 float64 = 1e-10
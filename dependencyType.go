@@ -8,25 +8,14 @@ import (
 // DependencyType represents the relation between two words
 type DependencyType byte
 
-var dependencyTypeLookup map[string]DependencyType
-
-func init() {
-	dependencyTypeLookup = make(map[string]DependencyType)
-	for dt := NoDepType; dt < MAXDEPTYPE; dt++ {
-		s := dt.String()
-		dependencyTypeLookup[s] = DependencyType(dt)
-		dependencyTypeLookup[strings.ToLower(s)] = DependencyType(dt)
-	}
-}
-
 func (dt DependencyType) MarshalText() ([]byte, error) {
 	return []byte(fmt.Sprintf("%v", dt)), nil
 }
 
 func (dt *DependencyType) UnmarshalText(text []byte) error {
 	str := strings.Trim(string(text), `"`) // for JSON use, if any
-	deptype, _ := dependencyTypeLookup[str]
-	*dt = deptype
+	rel, _ := UniversalRelations.Relation(str)
+	*dt = rel.DependencyType()
 	return nil
 }
 
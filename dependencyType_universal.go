@@ -1,7 +1,6 @@
-// +build !stanfordrel
-
 package lingua
 
+// BUILD_RELSET is the relation set of the DependencyType constants. Other relation sets are used through a RelationSet (e.g. StanfordRelations).
 const BUILD_RELSET = "universalrel"
 
 //go:generate stringer -type=DependencyType -output=dependencyType_universal_string.go
@@ -97,11 +96,39 @@ const (
 	Coordination // CC
 	CC_PreConj
 
+	// Universal Dependencies v2. The v1 relations that v2 replaces are kept above, so that UD v1 treebanks can still be read.
+	// See ToV2 and ToV1 for the conversion between them.
+	// http://universaldependencies.org/u/dep/all.html
+	Obj    // dobj in v1
+	Obl    // nmod of a predicate in v1
+	Clf    // classifier
+	Fixed  // mwe in v1
+	Flat   // name in v1
+	Orphan // remnant in v1
+
+	// subtypes of UD v2
+	NSubj_Pass // nsubjpass in v1
+	CSubj_Pass // csubjpass in v1
+	Aux_Pass   // auxpass in v1
+	Obl_TMod
+	Obl_NPMod
+	Obl_Agent
+	Obl_Arg
+	Flat_Name
+	Flat_Foreign // foreign in v1
+	Det_Poss
+	Compound_Redup // reduplication, e.g. in Indonesian
+	Compound_LVC
+	Compound_SVC
+	AdvMod_Emph
+	Expl_Pass
+	Expl_Impers
+
 	MAXDEPTYPE
 )
 
 var Modifiers = []DependencyType{AMod}
-var Compounds = []DependencyType{Compound, Compound_Part}
-var DeterminerRels = []DependencyType{Det, Det_PreDet}
-var MultiWord = []DependencyType{MWE, Compound, Compound_Part, Parataxis}
-var QuantifingMods = []DependencyType{NumMod}
+var Compounds = []DependencyType{Compound, Compound_Part, Compound_Redup, Compound_LVC, Compound_SVC}
+var DeterminerRels = []DependencyType{Det, Det_PreDet, Det_Poss}
+var MultiWord = []DependencyType{MWE, Fixed, Flat, Flat_Name, Flat_Foreign, Compound, Compound_Part, Compound_Redup, Parataxis}
+var QuantifingMods = []DependencyType{NumMod, Clf}
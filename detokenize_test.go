@@ -0,0 +1,68 @@
+package lingua
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var detokenizeTests = []string{
+	"Halo, dunia!",
+	"Harganya Rp1.250.000,50 saja.",
+	"\"Don't,\" katanya.  Lalu   ia pergi.",
+	"Baris pertama.\nBaris kedua.\n\nParagraf baru (dengan kurung)...",
+	"Tab\tdan spasi tak terputus juga.",
+	"Diakhiri spasi. ",
+	"Café “naïve” — 5€.",
+}
+
+func TestDetokenize(t *testing.T) {
+	for _, s := range detokenizeTests {
+		if got := Tokenize(s).Detokenize(); got != s {
+			t.Errorf("Expected %q after a round trip. Got %q instead", s, got)
+		}
+
+		// with the Space lexemes, even leading whitespace is kept
+		lexemes, err := NewTokenizer(strings.NewReader("  " + s)).Lexemes()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Detokenize(lexemes); got != "  "+s {
+			t.Errorf("Expected %q after a round trip. Got %q instead", "  "+s, got)
+		}
+	}
+}
+
+func TestAnnotatedSentence_Detokenize(t *testing.T) {
+	for _, s := range detokenizeTests {
+		sentences, err := NewPipeline().ProcessString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf strings.Builder
+		for _, as := range sentences {
+			buf.WriteString(as.Detokenize())
+		}
+		if buf.String() != s {
+			t.Errorf("Expected %q after a round trip. Got %q instead", s, buf.String())
+		}
+	}
+
+	// JSON keeps the whitespace
+	sentences, err := NewPipeline().ProcessString("Halo , dunia\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(sentences[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var as AnnotatedSentence
+	if err = json.Unmarshal(b, &as); err != nil {
+		t.Fatal(err)
+	}
+	if got := as.Detokenize(); got != "Halo , dunia\n" {
+		t.Errorf("Expected the whitespace to survive a round trip through JSON. Got %q", got)
+	}
+}
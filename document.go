@@ -0,0 +1,92 @@
+package lingua
+
+import "sort"
+
+// Document is a text and the sentences found in it. The Annotations of the sentences keep the Span of the text they cover,
+// which allows going from a token to the text and back.
+type Document struct {
+	Text      string
+	Sentences []AnnotatedSentence
+}
+
+// NewDocument creates a new *Document from a text and the sentences found in it.
+// The sentences are expected to be in the order of the text, and their Annotations to have their Spans filled in (as the Tokenizer does).
+func NewDocument(text string, sentences []AnnotatedSentence) *Document {
+	return &Document{
+		Text:      text,
+		Sentences: sentences,
+	}
+}
+
+// Document processes the text and returns a *Document.
+func (p *Pipeline) Document(text string) (*Document, error) {
+	sentences, err := p.ProcessString(text)
+	if err != nil {
+		return nil, err
+	}
+	return NewDocument(text, sentences), nil
+}
+
+// Locate returns the sentence and the position in the sentence of the Annotation that covers the byte offset.
+// ok is false if no Annotation covers the offset (e.g. the offset is in between words).
+func (d *Document) Locate(offset int) (sentence, word int, ok bool) {
+	return d.locate(offset, func(s Span) int { return s.End }, Span.Contains)
+}
+
+// LocateRune is like Locate, but the offset is in runes.
+func (d *Document) LocateRune(offset int) (sentence, word int, ok bool) {
+	return d.locate(offset, func(s Span) int { return s.RuneEnd }, Span.ContainsRune)
+}
+
+// At returns the Annotation that covers the byte offset, or nil if there is none.
+func (d *Document) At(offset int) *Annotation {
+	if s, w, ok := d.Locate(offset); ok {
+		return d.Sentences[s][w]
+	}
+	return nil
+}
+
+// AtRune returns the Annotation that covers the rune offset, or nil if there is none.
+func (d *Document) AtRune(offset int) *Annotation {
+	if s, w, ok := d.LocateRune(offset); ok {
+		return d.Sentences[s][w]
+	}
+	return nil
+}
+
+// TextOf returns the text that the Annotation covers. If the Annotation has no Span, an empty string is returned.
+func (d *Document) TextOf(a *Annotation) string { return d.SpanText(a.Span) }
+
+// SpanText returns the text covered by the span. Spans that are out of the bounds of the text return an empty string.
+func (d *Document) SpanText(s Span) string {
+	if s.IsZero() || s.Start < 0 || s.End > len(d.Text) {
+		return ""
+	}
+	return d.Text[s.Start:s.End]
+}
+
+// Between returns the text from the start of one Annotation to the end of another, e.g. to highlight an entity that spans many words.
+func (d *Document) Between(first, last *Annotation) string {
+	return d.SpanText(Span{Start: first.Span.Start, End: last.Span.End})
+}
+
+// locate finds the first Annotation whose end is after the offset. As the Annotations are in the order of the text, it is the only one that may contain the offset.
+func (d *Document) locate(offset int, end func(Span) int, contains func(Span, int) bool) (sentence, word int, ok bool) {
+	sentence = sort.Search(len(d.Sentences), func(i int) bool {
+		as := d.Sentences[i]
+		return len(as) > 0 && end(as[len(as)-1].Span) > offset
+	})
+	if sentence == len(d.Sentences) {
+		return -1, -1, false
+	}
+
+	as := d.Sentences[sentence]
+	word = sort.Search(len(as), func(i int) bool {
+		// the root annotation has no span
+		return !as[i].Span.IsZero() && end(as[i].Span) > offset
+	})
+	if word < len(as) && contains(as[word].Span, offset) {
+		return sentence, word, true
+	}
+	return -1, -1, false
+}
@@ -0,0 +1,80 @@
+package lingua
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	assert := assert.New(t)
+	text := "Kafé “Ani” buka.\n\nSudah makan?"
+	doc, err := NewPipeline().Document(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Equal(2, len(doc.Sentences)) {
+		t.FailNow()
+	}
+
+	for _, as := range doc.Sentences {
+		for _, a := range as[1:] {
+			assert.Equal(a.Value, doc.TextOf(a))
+		}
+	}
+	assert.Equal("", doc.TextOf(doc.Sentences[0][0]), "the root annotation covers no text")
+
+	// "Kafé" is 5 bytes and 4 runes long
+	a := doc.At(4)
+	if assert.NotNil(a) {
+		assert.Equal("Kafé", a.Value)
+	}
+	assert.Nil(doc.AtRune(4), "the space after Kafé")
+	if a = doc.AtRune(6); assert.NotNil(a) {
+		assert.Equal("Ani", a.Value)
+	}
+
+	s, w, ok := doc.Locate(len(text) - 1)
+	assert.True(ok)
+	assert.Equal(1, s)
+	assert.Equal("?", doc.Sentences[s][w].Value)
+
+	_, _, ok = doc.Locate(len(text))
+	assert.False(ok)
+
+	first, last := doc.Sentences[1][1], doc.Sentences[1][2]
+	assert.Equal("Sudah makan", doc.Between(first, last))
+}
+
+func TestAnnotationJSON_Span(t *testing.T) {
+	doc, err := NewPipeline().Document("Halo dunia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := doc.Sentences[0][2]
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewAnnotation()
+	if err = json.Unmarshal(b, c); err != nil {
+		t.Fatal(err)
+	}
+	if c.Span != (Span{5, 10, 5, 10}) {
+		t.Errorf("Expected the span to survive a round trip through JSON. Got %v instead. JSON: %s", c.Span, b)
+	}
+
+	if b, err = json.Marshal(StringToAnnotation("halo", nil)); err != nil {
+		t.Fatal(err)
+	}
+	var m map[string]interface{}
+	if err = json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m["Start"]; ok {
+		t.Errorf("Expected no offsets in the JSON of an annotation without a span. Got %s", b)
+	}
+}
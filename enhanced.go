@@ -0,0 +1,326 @@
+package lingua
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// NodeID identifies a node of an EnhancedGraph. A word is identified by its ID. An empty node is identified by the ID of
+// the word it follows and its index, so the empty node 4.1 is NodeID{4, 1}. The root is the zero NodeID.
+type NodeID struct {
+	Word, Empty int
+}
+
+// WordNode returns the NodeID of the word with the ID
+func WordNode(id int) NodeID { return NodeID{Word: id} }
+
+// IsEmpty checks if the node is an empty node
+func (n NodeID) IsEmpty() bool { return n.Empty > 0 }
+
+func (n NodeID) String() string {
+	if n.IsEmpty() {
+		return fmt.Sprintf("%d.%d", n.Word, n.Empty)
+	}
+	return strconv.Itoa(n.Word)
+}
+
+// Less orders the nodes as they are in the sentence: an empty node comes after the word it follows.
+func (n NodeID) Less(other NodeID) bool {
+	if n.Word != other.Word {
+		return n.Word < other.Word
+	}
+	return n.Empty < other.Empty
+}
+
+// ParseNodeID parses a NodeID, as written in the ID and DEPS columns of a CONLLU file (e.g. "4" or "4.1").
+func ParseNodeID(s string) (NodeID, error) {
+	parts := strings.SplitN(s, ".", 2)
+	word, err := strconv.Atoi(parts[0])
+	if err != nil || word < 0 {
+		return NodeID{}, errors.Errorf("Invalid node ID %q", s)
+	}
+	if len(parts) == 1 {
+		return NodeID{Word: word}, nil
+	}
+	empty, err := strconv.Atoi(parts[1])
+	if err != nil || empty < 1 {
+		return NodeID{}, errors.Errorf("Invalid node ID %q", s)
+	}
+	return NodeID{word, empty}, nil
+}
+
+// EnhancedEdge is a labeled edge of an EnhancedGraph. The Label is kept as it is written, with its subtypes (e.g. obl:dengan, nmod:poss).
+type EnhancedEdge struct {
+	Head, Dependent NodeID
+	Label           string
+}
+
+// Relation returns the DependencyType of the label without its subtypes. An unknown label is a NoDepType.
+func (e EnhancedEdge) Relation() DependencyType {
+	rel, _ := UniversalRelations.Relation(strings.SplitN(e.Label, ":", 2)[0])
+	return rel.DependencyType()
+}
+
+// Subtype returns the subtypes of the label (e.g. "dengan" for obl:dengan), if any.
+func (e EnhancedEdge) Subtype() string {
+	if parts := strings.SplitN(e.Label, ":", 2); len(parts) == 2 {
+		return parts[1]
+	}
+	return ""
+}
+
+func (e EnhancedEdge) String() string { return fmt.Sprintf("%v(%v, %v)", e.Label, e.Head, e.Dependent) }
+
+// ParseDeps parses the DEPS column of a CONLLU file (e.g. "2:nsubj|4.1:obl:dengan") into the edges to the dependent.
+// "_" and "" have no edges.
+func ParseDeps(dependent NodeID, deps string) ([]EnhancedEdge, error) {
+	if deps == "" || deps == "_" {
+		return nil, nil
+	}
+
+	var retVal []EnhancedEdge
+	for _, hl := range strings.Split(deps, "|") {
+		parts := strings.SplitN(hl, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.Errorf("Invalid dependency %q in %q", hl, deps)
+		}
+		head, err := ParseNodeID(parts[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid dependency %q in %q", hl, deps)
+		}
+		retVal = append(retVal, EnhancedEdge{head, dependent, parts[1]})
+	}
+	return retVal, nil
+}
+
+// FormatDeps formats the edges as the DEPS column of a CONLLU file, sorted by head. No edges are an empty string.
+func FormatDeps(edges []EnhancedEdge) string {
+	edges = append([]EnhancedEdge(nil), edges...)
+	sortEdges(edges, func(e EnhancedEdge) NodeID { return e.Head })
+
+	strs := make([]string, len(edges))
+	for i, e := range edges {
+		strs[i] = e.Head.String() + ":" + e.Label
+	}
+	return strings.Join(strs, "|")
+}
+
+// EnhancedGraph is the enhanced dependency graph of a sentence, as in the DEPS column of a CONLLU file.
+// Unlike the basic dependency tree of an AnnotatedSentence (see Dependency), a word may have many heads (or none),
+// the graph may have cycles, and there may be empty nodes for words that are elided in the text.
+//
+// The graph is kept apart from the Heads of the Annotations, so the basic tree is left as it is.
+type EnhancedGraph struct {
+	AnnotatedSentence
+
+	empty    map[NodeID]*Annotation
+	heads    map[NodeID][]EnhancedEdge // the incoming edges of each node
+	children map[NodeID][]EnhancedEdge // the outgoing edges of each node
+}
+
+// NewEnhancedGraph creates a new *EnhancedGraph without edges for the sentence. The sentence is expected to start with the root annotation,
+// so that the ID of a word is its position in the sentence, as it is for the sentences of a Pipeline.
+func NewEnhancedGraph(s AnnotatedSentence) *EnhancedGraph {
+	return &EnhancedGraph{
+		AnnotatedSentence: s,
+		empty:             make(map[NodeID]*Annotation),
+		heads:             make(map[NodeID][]EnhancedEdge),
+		children:          make(map[NodeID][]EnhancedEdge),
+	}
+}
+
+// AddEmptyNode adds an empty node to the graph. The word that the empty node follows has to be in the sentence.
+func (g *EnhancedGraph) AddEmptyNode(id NodeID, a *Annotation) error {
+	if !id.IsEmpty() {
+		return errors.Errorf("%v is not an empty node", id)
+	}
+	if id.Word >= len(g.AnnotatedSentence) {
+		return errors.Errorf("Empty node %v follows a word that is not in the sentence", id)
+	}
+	g.empty[id] = a
+	return nil
+}
+
+// AddEdge adds a labeled edge between two nodes of the graph. Adding an edge that is already in the graph does nothing.
+func (g *EnhancedGraph) AddEdge(head, dependent NodeID, label string) error {
+	if !g.Has(head) {
+		return errors.Errorf("Head %v is not in the graph", head)
+	}
+	if !g.Has(dependent) || dependent == (NodeID{}) {
+		return errors.Errorf("Dependent %v is not in the graph", dependent)
+	}
+
+	e := EnhancedEdge{head, dependent, label}
+	for _, other := range g.heads[dependent] {
+		if other == e {
+			return nil
+		}
+	}
+	g.heads[dependent] = append(g.heads[dependent], e)
+	g.children[head] = append(g.children[head], e)
+	return nil
+}
+
+// SetDeps adds the edges of a DEPS column to the dependent.
+func (g *EnhancedGraph) SetDeps(dependent NodeID, deps string) error {
+	edges, err := ParseDeps(dependent, deps)
+	if err != nil {
+		return err
+	}
+	for _, e := range edges {
+		if err = g.AddEdge(e.Head, e.Dependent, e.Label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deps returns the edges to the node as a DEPS column. See FormatDeps.
+func (g *EnhancedGraph) Deps(id NodeID) string { return FormatDeps(g.heads[id]) }
+
+// Has checks if the node is in the graph
+func (g *EnhancedGraph) Has(id NodeID) bool {
+	if id.IsEmpty() {
+		_, ok := g.empty[id]
+		return ok
+	}
+	return id.Word >= 0 && id.Word < len(g.AnnotatedSentence)
+}
+
+// Node returns the Annotation of the node, or nil if the node is not in the graph.
+func (g *EnhancedGraph) Node(id NodeID) *Annotation {
+	if id.IsEmpty() {
+		return g.empty[id]
+	}
+	if id.Word >= 0 && id.Word < len(g.AnnotatedSentence) {
+		return g.AnnotatedSentence[id.Word]
+	}
+	return nil
+}
+
+// Nodes returns all the nodes of the graph in the order of the sentence, starting with the root.
+func (g *EnhancedGraph) Nodes() []NodeID {
+	retVal := make([]NodeID, 0, len(g.AnnotatedSentence)+len(g.empty))
+	for i := range g.AnnotatedSentence {
+		retVal = append(retVal, WordNode(i))
+	}
+	retVal = append(retVal, g.EmptyNodes()...)
+	sort.Slice(retVal, func(i, j int) bool { return retVal[i].Less(retVal[j]) })
+	return retVal
+}
+
+// EmptyNodes returns the empty nodes of the graph in the order of the sentence.
+func (g *EnhancedGraph) EmptyNodes() []NodeID {
+	retVal := make([]NodeID, 0, len(g.empty))
+	for id := range g.empty {
+		retVal = append(retVal, id)
+	}
+	sort.Slice(retVal, func(i, j int) bool { return retVal[i].Less(retVal[j]) })
+	return retVal
+}
+
+// Heads returns the edges to the node, ordered by head.
+func (g *EnhancedGraph) Heads(id NodeID) []EnhancedEdge {
+	retVal := append([]EnhancedEdge(nil), g.heads[id]...)
+	sortEdges(retVal, func(e EnhancedEdge) NodeID { return e.Head })
+	return retVal
+}
+
+// Children returns the edges from the node, ordered by dependent.
+func (g *EnhancedGraph) Children(id NodeID) []EnhancedEdge {
+	retVal := append([]EnhancedEdge(nil), g.children[id]...)
+	sortEdges(retVal, func(e EnhancedEdge) NodeID { return e.Dependent })
+	return retVal
+}
+
+// Edges returns all the edges of the graph, ordered by dependent and then by head.
+func (g *EnhancedGraph) Edges() []EnhancedEdge {
+	var retVal []EnhancedEdge
+	for _, id := range g.Nodes() {
+		retVal = append(retVal, g.Heads(id)...)
+	}
+	return retVal
+}
+
+// Walk visits the edges reachable from a node, breadth first, following the direction of the edges.
+// Every node is expanded at most once, so cycles are fine. The walk stops when fn returns false.
+func (g *EnhancedGraph) Walk(from NodeID, fn func(EnhancedEdge) bool) {
+	seen := map[NodeID]bool{from: true}
+	queue := []NodeID{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, e := range g.Children(id) {
+			if !fn(e) {
+				return
+			}
+			if !seen[e.Dependent] {
+				seen[e.Dependent] = true
+				queue = append(queue, e.Dependent)
+			}
+		}
+	}
+}
+
+// Path returns the shortest path of edges between two nodes, regardless of the direction of the edges
+// (e.g. the path from a subject to an object goes up to their verb and down again). nil is returned if there is no path.
+func (g *EnhancedGraph) Path(from, to NodeID) []EnhancedEdge {
+	if from == to || !g.Has(from) || !g.Has(to) {
+		return nil
+	}
+
+	via := map[NodeID]EnhancedEdge{}
+	seen := map[NodeID]bool{from: true}
+	queue := []NodeID{from}
+	for len(queue) > 0 && !seen[to] {
+		id := queue[0]
+		queue = queue[1:]
+
+		next := func(e EnhancedEdge, n NodeID) {
+			if !seen[n] {
+				seen[n] = true
+				via[n] = e
+				queue = append(queue, n)
+			}
+		}
+		for _, e := range g.Heads(id) {
+			next(e, e.Head)
+		}
+		for _, e := range g.Children(id) {
+			next(e, e.Dependent)
+		}
+	}
+	if !seen[to] {
+		return nil
+	}
+
+	var retVal []EnhancedEdge
+	for n := to; n != from; {
+		e := via[n]
+		retVal = append(retVal, e)
+		if e.Head == n {
+			n = e.Dependent
+		} else {
+			n = e.Head
+		}
+	}
+	for i, j := 0, len(retVal)-1; i < j; i, j = i+1, j-1 {
+		retVal[i], retVal[j] = retVal[j], retVal[i]
+	}
+	return retVal
+}
+
+// sortEdges sorts the edges by a node of the edge, and then by label
+func sortEdges(edges []EnhancedEdge, by func(EnhancedEdge) NodeID) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := by(edges[i]), by(edges[j])
+		if a != b {
+			return a.Less(b)
+		}
+		return edges[i].Label < edges[j].Label
+	})
+}
@@ -0,0 +1,147 @@
+package lingua
+
+import (
+	"reflect"
+	"testing"
+)
+
+func enhancedTestGraph(t *testing.T) *EnhancedGraph {
+	// Ani makan dan minum
+	as := AnnotatedSentence{rootAnnotation}
+	for i, w := range []string{"Ani", "makan", "dan", "minum"} {
+		a := AnnotationFromLexTag(MakeLexeme(w, Word), X, nil)
+		a.ID = i + 1
+		as = append(as, a)
+	}
+
+	g := NewEnhancedGraph(as)
+	deps := []string{"2:nsubj|4:nsubj", "0:root", "4:cc", "2:conj:dan"}
+	for i, d := range deps {
+		if err := g.SetDeps(WordNode(i+1), d); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return g
+}
+
+func TestParseNodeID(t *testing.T) {
+	for _, s := range []string{"0", "4", "4.1", "12.10"} {
+		id, err := ParseNodeID(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id.String() != s {
+			t.Errorf("Expected %q. Got %v instead", s, id)
+		}
+	}
+	for _, s := range []string{"", "a", "4.0", "4.", "-1"} {
+		if _, err := ParseNodeID(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestParseDeps(t *testing.T) {
+	edges, err := ParseDeps(WordNode(5), "4.1:nmod:dengan|2:nsubj")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []EnhancedEdge{{NodeID{4, 1}, WordNode(5), "nmod:dengan"}, {WordNode(2), WordNode(5), "nsubj"}}
+	if !reflect.DeepEqual(edges, expected) {
+		t.Errorf("Expected %v. Got %v instead", expected, edges)
+	}
+	if FormatDeps(edges) != "2:nsubj|4.1:nmod:dengan" {
+		t.Errorf("Expected the deps to be sorted by head. Got %q", FormatDeps(edges))
+	}
+	if edges[0].Relation() != NMod || edges[0].Subtype() != "dengan" {
+		t.Errorf("Unexpected relation %v and subtype %q", edges[0].Relation(), edges[0].Subtype())
+	}
+
+	for _, deps := range []string{"2", "2:", "x:nsubj", "2:nsubj|"} {
+		if _, err = ParseDeps(WordNode(1), deps); err == nil {
+			t.Errorf("Expected an error for %q", deps)
+		}
+	}
+}
+
+func TestEnhancedGraph(t *testing.T) {
+	g := enhancedTestGraph(t)
+
+	if heads := g.Heads(WordNode(1)); len(heads) != 2 || heads[0].Head != WordNode(2) || heads[1].Head != WordNode(4) {
+		t.Errorf("Expected Ani to have two heads. Got %v", heads)
+	}
+	if g.Deps(WordNode(1)) != "2:nsubj|4:nsubj" {
+		t.Errorf("Unexpected deps %q", g.Deps(WordNode(1)))
+	}
+	if children := g.Children(WordNode(4)); len(children) != 2 || children[0].Label != "nsubj" || children[1].Label != "cc" {
+		t.Errorf("Unexpected children of minum: %v", children)
+	}
+	if len(g.Edges()) != 5 {
+		t.Errorf("Expected 5 edges. Got %v", g.Edges())
+	}
+
+	// the basic tree is left alone
+	for _, a := range g.AnnotatedSentence[1:] {
+		if a.Head != nil {
+			t.Errorf("Expected %v to have no basic head", a)
+		}
+	}
+
+	// adding an edge twice does nothing
+	if err := g.AddEdge(WordNode(2), WordNode(1), "nsubj"); err != nil || len(g.Heads(WordNode(1))) != 2 {
+		t.Errorf("Expected the edge not to be added twice: %v", g.Heads(WordNode(1)))
+	}
+	if err := g.AddEdge(WordNode(9), WordNode(1), "dep"); err == nil {
+		t.Error("Expected an error for a head that isn't in the graph")
+	}
+	if err := g.AddEdge(WordNode(1), NodeID{}, "dep"); err == nil {
+		t.Error("Expected an error for the root as a dependent")
+	}
+
+	// an elided verb
+	empty := NodeID{4, 1}
+	if err := g.AddEmptyNode(empty, AnnotationFromLexTag(MakeLexeme("makan", Word), VERB, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := g.AddEdge(WordNode(2), empty, "conj"); err != nil {
+		t.Fatal(err)
+	}
+	if nodes := g.Nodes(); len(nodes) != 6 || nodes[5] != empty {
+		t.Errorf("Unexpected nodes %v", nodes)
+	}
+	if g.Node(empty).Value != "makan" || g.Node(NodeID{4, 2}) != nil {
+		t.Error("Unexpected Node")
+	}
+}
+
+func TestEnhancedGraph_Traversal(t *testing.T) {
+	g := enhancedTestGraph(t)
+
+	var visited []string
+	g.Walk(NodeID{}, func(e EnhancedEdge) bool {
+		visited = append(visited, e.String())
+		return true
+	})
+	expected := []string{"root(0, 2)", "nsubj(2, 1)", "conj:dan(2, 4)", "nsubj(4, 1)", "cc(4, 3)"}
+	if !reflect.DeepEqual(visited, expected) {
+		t.Errorf("Expected %v. Got %v instead", expected, visited)
+	}
+
+	count := 0
+	g.Walk(NodeID{}, func(e EnhancedEdge) bool {
+		count++
+		return count < 2
+	})
+	if count != 2 {
+		t.Errorf("Expected the walk to stop. Got %d edges", count)
+	}
+
+	// dan -> minum -> Ani
+	path := g.Path(WordNode(3), WordNode(1))
+	if len(path) != 2 || path[0].Label != "cc" || path[1].Label != "nsubj" || path[1].Head != WordNode(4) {
+		t.Errorf("Unexpected path %v", path)
+	}
+	if g.Path(WordNode(1), WordNode(1)) != nil || g.Path(WordNode(1), WordNode(9)) != nil {
+		t.Error("Expected no path")
+	}
+}
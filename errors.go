@@ -1,6 +1,13 @@
 package lingua
 
+import "github.com/pkg/errors"
+
 type componentUnavailable interface {
 	error
 	Component() string
 }
+
+func isUnavailable(err error) bool {
+	_, ok := errors.Cause(err).(componentUnavailable)
+	return ok
+}
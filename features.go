@@ -0,0 +1,160 @@
+package lingua
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Feature is a morphological feature of a word, as written in the FEATS column of a CONLLU file (e.g. Number=Plur).
+// A feature may have many values, separated by commas (e.g. PronType=Int,Rel).
+type Feature struct {
+	Name, Value string
+}
+
+func (f Feature) String() string { return f.Name + "=" + f.Value }
+
+// Features is a set of morphological features, sorted by name. The nil Features is the empty set.
+//
+// Features are treated as values: the methods that change the set return a new Features, and leave the receiver alone.
+type Features []Feature
+
+// ParseFeatures parses the features as written in the FEATS column of a CONLLU file:
+//		Number=Plur|Voice=Pass
+// "_" and "" are the empty set. The features are put in the canonical order: sorted by name,
+// and the values of a feature sorted, as the UD guidelines require.
+func ParseFeatures(s string) (Features, error) {
+	if s == "" || s == "_" {
+		return nil, nil
+	}
+
+	kvs := strings.Split(s, "|")
+	retVal := make(Features, 0, len(kvs))
+	for _, kv := range kvs {
+		nv := strings.SplitN(kv, "=", 2)
+		if len(nv) != 2 || nv[0] == "" || nv[1] == "" {
+			return nil, errors.Errorf("Invalid feature %q in %q", kv, s)
+		}
+		if _, ok := retVal.Get(nv[0]); ok {
+			return nil, errors.Errorf("Feature %q is repeated in %q", nv[0], s)
+		}
+		retVal = append(retVal, Feature{nv[0], canonicalValue(nv[1])})
+	}
+	sort.Sort(retVal)
+	return retVal, nil
+}
+
+// String returns the features in their canonical form. The empty set is an empty string.
+func (fs Features) String() string {
+	strs := make([]string, len(fs))
+	for i, f := range fs {
+		strs[i] = f.String()
+	}
+	return strings.Join(strs, "|")
+}
+
+// Get returns the value of a feature.
+func (fs Features) Get(name string) (string, bool) {
+	for _, f := range fs {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+// Has checks if a feature has the value. For features with many values, the value needs to be only one of them.
+func (fs Features) Has(name, value string) bool {
+	v, ok := fs.Get(name)
+	if !ok {
+		return false
+	}
+	return InStringSlice(value, strings.Split(v, ","))
+}
+
+// With returns the features with the feature set to the value. An empty value removes the feature.
+func (fs Features) With(name, value string) Features {
+	retVal := fs.Without(name)
+	if value == "" {
+		return retVal
+	}
+	retVal = append(retVal, Feature{name, canonicalValue(value)})
+	sort.Sort(retVal)
+	return retVal
+}
+
+// Without returns the features without the named features.
+func (fs Features) Without(names ...string) Features {
+	var retVal Features
+	for _, f := range fs {
+		if !InStringSlice(f.Name, names) {
+			retVal = append(retVal, f)
+		}
+	}
+	return retVal
+}
+
+// Merge returns the union of the features. Where both sets have a feature, the value of other is used.
+func (fs Features) Merge(other Features) Features {
+	retVal := fs
+	for _, f := range other {
+		retVal = retVal.With(f.Name, f.Value)
+	}
+	return retVal
+}
+
+// Intersect returns the features that are in both sets, with the same value.
+func (fs Features) Intersect(other Features) Features {
+	var retVal Features
+	for _, f := range fs {
+		if v, ok := other.Get(f.Name); ok && v == f.Value {
+			retVal = append(retVal, f)
+		}
+	}
+	return retVal
+}
+
+// Contains checks if all the features of other are in the set, with the same value.
+func (fs Features) Contains(other Features) bool {
+	return len(other.Intersect(fs)) == len(other)
+}
+
+// Equal checks if both sets have the same features.
+func (fs Features) Equal(other Features) bool {
+	return len(fs) == len(other) && fs.Contains(other)
+}
+
+func (fs Features) Len() int           { return len(fs) }
+func (fs Features) Less(i, j int) bool { return lessFold(fs[i].Name, fs[j].Name) }
+func (fs Features) Swap(i, j int)      { fs[i], fs[j] = fs[j], fs[i] }
+
+func (fs Features) MarshalText() ([]byte, error) { return []byte(fs.String()), nil }
+
+func (fs *Features) UnmarshalText(text []byte) error {
+	f, err := ParseFeatures(string(text))
+	if err != nil {
+		return err
+	}
+	*fs = f
+	return nil
+}
+
+// canonicalValue sorts the values of a feature with many values
+func canonicalValue(v string) string {
+	if !strings.Contains(v, ",") {
+		return v
+	}
+	vals := strings.Split(v, ",")
+	sort.Slice(vals, func(i, j int) bool { return lessFold(vals[i], vals[j]) })
+	return strings.Join(vals, ",")
+}
+
+// lessFold compares strings case insensitively, as the features are ordered in UD
+func lessFold(a, b string) bool {
+	la, lb := strings.ToLower(a), strings.ToLower(b)
+	if la == lb {
+		return a < b
+	}
+	return la < lb
+}
@@ -0,0 +1,98 @@
+package lingua
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseFeatures(t *testing.T) {
+	tests := []struct {
+		in, canonical string
+		err           bool
+	}{
+		{"_", "", false},
+		{"", "", false},
+		{"Voice=Pass|Number=Plur", "Number=Plur|Voice=Pass", false},
+		{"PronType=Rel,Int", "PronType=Int,Rel", false},
+		{"Number[psor]=Sing|Number=Plur", "Number=Plur|Number[psor]=Sing", false},
+		{"Number", "", true},
+		{"Number=", "", true},
+		{"Number=Plur|Number=Sing", "", true},
+	}
+	for _, tt := range tests {
+		fs, err := ParseFeatures(tt.in)
+		switch {
+		case tt.err && err == nil:
+			t.Errorf("Expected an error for %q", tt.in)
+		case !tt.err && err != nil:
+			t.Errorf("Parsing %q: %v", tt.in, err)
+		case fs.String() != tt.canonical:
+			t.Errorf("Expected %q to be %q. Got %q instead", tt.in, tt.canonical, fs)
+		}
+	}
+}
+
+func TestFeatures_Sets(t *testing.T) {
+	fs, _ := ParseFeatures("Number=Plur|PronType=Int,Rel")
+
+	if v, ok := fs.Get("Number"); !ok || v != "Plur" {
+		t.Errorf("Expected Number=Plur. Got %q", v)
+	}
+	if !fs.Has("PronType", "Rel") || fs.Has("PronType", "Dem") || fs.Has("Voice", "Pass") {
+		t.Errorf("Has is wrong for %v", fs)
+	}
+
+	with := fs.With("Voice", "Pass").With("Number", "Sing")
+	if with.String() != "Number=Sing|PronType=Int,Rel|Voice=Pass" {
+		t.Errorf("Unexpected With: %v", with)
+	}
+	if fs.String() != "Number=Plur|PronType=Int,Rel" {
+		t.Errorf("With changed the receiver: %v", fs)
+	}
+	if without := with.Without("Number", "Voice"); without.String() != "PronType=Int,Rel" {
+		t.Errorf("Unexpected Without: %v", without)
+	}
+	if removed := fs.With("Number", ""); removed.String() != "PronType=Int,Rel" {
+		t.Errorf("Expected an empty value to remove the feature. Got %v", removed)
+	}
+
+	other, _ := ParseFeatures("Number=Plur|Polite=Form")
+	if merged := fs.Merge(other); merged.String() != "Number=Plur|Polite=Form|PronType=Int,Rel" {
+		t.Errorf("Unexpected Merge: %v", merged)
+	}
+	if in := fs.Intersect(other); in.String() != "Number=Plur" {
+		t.Errorf("Unexpected Intersect: %v", in)
+	}
+	if !fs.Contains(fs.Intersect(other)) || fs.Contains(other) {
+		t.Errorf("Contains is wrong for %v", fs)
+	}
+
+	same, _ := ParseFeatures("PronType=Rel,Int|Number=Plur")
+	if !fs.Equal(same) || fs.Equal(other) {
+		t.Errorf("Equal is wrong for %v", fs)
+	}
+}
+
+func TestFeaturesJSON(t *testing.T) {
+	a := NewAnnotation()
+	a.Value = "dibaca"
+	a.POSTag = VERB
+	a.Features, _ = ParseFeatures("Voice=Pass|Polite=Form")
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewAnnotation()
+	if err = json.Unmarshal(b, c); err != nil {
+		t.Fatal(err)
+	}
+	if !c.Features.Equal(a.Features) {
+		t.Errorf("Expected Features to be %v. Got %v instead", a.Features, c.Features)
+	}
+
+	if err = json.Unmarshal([]byte(`{"Value":"dibaca","Features":"Voice"}`), c); err == nil {
+		t.Error("Expected an error for invalid features")
+	}
+}
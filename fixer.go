@@ -0,0 +1,111 @@
+package lingua
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ComponentUnavailableError is the error returned when a component (a stemmer, a lemmatizer, clusters, a tagger...) is not available.
+// Annotation.Process and Pipeline skip components that return this error.
+type ComponentUnavailableError struct {
+	Name string
+}
+
+func (err ComponentUnavailableError) Error() string {
+	return fmt.Sprintf("%v is unavailable", err.Name)
+}
+
+func (err ComponentUnavailableError) Component() string { return err.Name }
+
+// Fixer is an AnnotationFixer made of optional components. Components that are not provided return a ComponentUnavailableError.
+//
+// The clusters are read at most once, so a *Fixer can be shared by all the annotations that are processed.
+type Fixer struct {
+	lemmatizer Lemmatizer
+	stemmer    Stemmer
+
+	clusters   map[string]Cluster
+	clusterSrc io.Reader
+	clusterErr error
+	once       sync.Once
+}
+
+type fixerConsOpt func(*Fixer)
+
+// WithLemmatizer sets the Lemmatizer of the fixer
+func WithLemmatizer(l Lemmatizer) fixerConsOpt {
+	return func(f *Fixer) { f.lemmatizer = l }
+}
+
+// WithStemmer sets the Stemmer of the fixer
+func WithStemmer(s Stemmer) fixerConsOpt {
+	return func(f *Fixer) { f.stemmer = s }
+}
+
+// WithClusters sets the clusters of the fixer
+func WithClusters(c map[string]Cluster) fixerConsOpt {
+	return func(f *Fixer) { f.clusters = c }
+}
+
+// WithClusterReader makes the fixer read the clusters with ReadCluster when they're first asked for
+func WithClusterReader(r io.Reader) fixerConsOpt {
+	return func(f *Fixer) { f.clusterSrc = r }
+}
+
+// NewFixer creates a new *Fixer. It takes optional construction options:
+//		WithLemmatizer
+//		WithStemmer
+//		WithClusters
+//		WithClusterReader
+func NewFixer(opts ...fixerConsOpt) *Fixer {
+	f := new(Fixer)
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Lemmatize lemmatizes the word with the Lemmatizer of the fixer
+func (f *Fixer) Lemmatize(word string, tag POSTag) ([]string, error) {
+	if f.lemmatizer == nil {
+		return nil, ComponentUnavailableError{"lemmatizer"}
+	}
+	return f.lemmatizer.Lemmatize(word, tag)
+}
+
+// Stem stems the word with the Stemmer of the fixer
+func (f *Fixer) Stem(word string) (string, error) {
+	if f.stemmer == nil {
+		return "", ComponentUnavailableError{"stemmer"}
+	}
+	return f.stemmer.Stem(word)
+}
+
+// Clusters returns the clusters of the fixer. If the fixer was created WithClusterReader, the clusters are read on the first call and cached.
+func (f *Fixer) Clusters() (map[string]Cluster, error) {
+	f.once.Do(f.readClusters)
+	if f.clusterErr != nil {
+		return nil, f.clusterErr
+	}
+	if f.clusters == nil {
+		return nil, ComponentUnavailableError{"clusters"}
+	}
+	return f.clusters, nil
+}
+
+func (f *Fixer) readClusters() {
+	if f.clusterSrc == nil {
+		return
+	}
+	defer func() {
+		// ReadCluster panics on malformed files
+		if r := recover(); r != nil {
+			f.clusterErr = errors.Errorf("Unable to read clusters: %v", r)
+		}
+	}()
+	f.clusters = ReadCluster(f.clusterSrc)
+	f.clusterSrc = nil
+}
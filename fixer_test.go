@@ -0,0 +1,57 @@
+package lingua
+
+import (
+	"strings"
+	"testing"
+)
+
+var _ AnnotationFixer = &Fixer{}
+
+const clusterFile = "0010\tbuku\t10\n0011\tmeja\t5\n01\tlangka\t1\n"
+
+func TestFixer(t *testing.T) {
+	f := NewFixer()
+	if _, err := f.Stem("buku"); !isUnavailable(err) {
+		t.Errorf("Expected the stemmer to be unavailable. Got %v instead", err)
+	}
+	if _, err := f.Lemmatize("buku", NOUN); !isUnavailable(err) {
+		t.Errorf("Expected the lemmatizer to be unavailable. Got %v instead", err)
+	}
+	if _, err := f.Clusters(); !isUnavailable(err) {
+		t.Errorf("Expected the clusters to be unavailable. Got %v instead", err)
+	}
+
+	// an empty fixer is still usable
+	a := StringToAnnotation("Buku", f)
+	if a.Lowered != "buku" || a.Stem != "" || a.Cluster != 0 {
+		t.Errorf("Unexpected annotation %#v", a)
+	}
+}
+
+func TestFixer_Clusters(t *testing.T) {
+	f := NewFixer(WithClusterReader(strings.NewReader(clusterFile)))
+	c1, err := f.Clusters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c2, err := f.Clusters()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c1["cached"] = 1
+	if _, ok := c2["cached"]; !ok {
+		t.Error("Expected the clusters to be read once and cached")
+	}
+
+	if a := StringToAnnotation("buku", f); a.Cluster != Cluster(2) {
+		t.Errorf("Expected the cluster of buku to be 2. Got %v instead", a.Cluster)
+	}
+	if a := StringToAnnotation("langka", f); a.Cluster != Cluster(0) {
+		t.Errorf("Expected the cluster of a rare word to be 0. Got %v instead", a.Cluster)
+	}
+
+	f = NewFixer(WithClusterReader(strings.NewReader("not a cluster file\n")))
+	if _, err := f.Clusters(); err == nil || isUnavailable(err) {
+		t.Errorf("Expected an error reading a malformed cluster file. Got %v instead", err)
+	}
+}
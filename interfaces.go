@@ -2,6 +2,7 @@ package lingua
 
 import (
 	"encoding/gob"
+	"io"
 
 	"gorgonia.org/tensor"
 )
@@ -21,6 +22,26 @@ type Sentencer interface {
 	Sentence() AnnotatedSentence
 }
 
+// Lexer is anything that can break a text into Lexemes
+type Lexer interface {
+	Lex(io.Reader) (LexemeSentence, error)
+}
+
+// Splitter is anything that can split Lexemes into sentences
+type Splitter interface {
+	Split(LexemeSentence) []LexemeSentence
+}
+
+// Tagger is anything that can set the POSTags of an AnnotatedSentence
+type Tagger interface {
+	Tag(AnnotatedSentence) error
+}
+
+// Parser is anything that can parse the dependencies of an AnnotatedSentence
+type Parser interface {
+	Parse(AnnotatedSentence) (*Dependency, error)
+}
+
 // Corpus is the interface for the corpus.
 type Corpus interface {
 	// ID returns the ID of a word and whether or not it was found in the corpus
@@ -0,0 +1,408 @@
+package lingua
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// inventory is a list of names, and a lookup of the names and their aliases
+type inventory struct {
+	names  []string
+	lookup map[string]uint16
+}
+
+func newInventory(names []string) (inventory, error) {
+	if len(names) == 0 || len(names) > 1<<16 {
+		return inventory{}, errors.Errorf("Expected between 1 and %d names. Got %d", 1<<16, len(names))
+	}
+	inv := inventory{
+		names:  append([]string(nil), names...),
+		lookup: make(map[string]uint16),
+	}
+	for i, name := range names {
+		if _, ok := inv.lookup[name]; ok {
+			return inventory{}, errors.Errorf("%q is repeated", name)
+		}
+		inv.lookup[name] = uint16(i)
+	}
+	// lower cased names are added after all the names, so they don't shadow any name
+	for i, name := range names {
+		inv.alias(strings.ToLower(name), uint16(i))
+	}
+	return inv, nil
+}
+
+// alias adds another name for the ith name, unless the alias is already taken
+func (inv inventory) alias(alias string, i uint16) {
+	if _, ok := inv.lookup[alias]; !ok {
+		inv.lookup[alias] = i
+	}
+}
+
+// index finds the index of a name or an alias. Names are looked up as they are, then lower cased.
+func (inv inventory) index(name string) (uint16, bool) {
+	if i, ok := inv.lookup[name]; ok {
+		return i, true
+	}
+	i, ok := inv.lookup[strings.ToLower(name)]
+	return i, ok
+}
+
+func (inv inventory) name(i uint16) (string, bool) {
+	if int(i) >= len(inv.names) {
+		return "", false
+	}
+	return inv.names[i], true
+}
+
+// TagSetID identifies a registered TagSet. The zero TagSetID is UniversalTags.
+type TagSetID byte
+
+// TagSet is a named inventory of POS tags, such as the Universal Dependencies tags (UniversalTags) or the Penn Treebank tags (PennTags).
+// Every tag of a TagSet maps to a coarse POSTag, so that the functions on POSTags (IsNoun, IsVerb, etc) work with any TagSet.
+//
+// TagSets are registered with RegisterTagSet, so that models with different TagSets can be used in the same program.
+type TagSet struct {
+	ID   TagSetID
+	Name string
+
+	inventory
+	coarse []POSTag
+}
+
+// Tag is a tag of a TagSet. Unlike a POSTag, a Tag carries the ID of its TagSet: it is the tagset-aware POSTag,
+// while POSTag stays the coarse tag that the rest of the package works with (see Tag.POSTag and TagSet.FromPOSTag).
+// The first tag of every TagSet is its null tag, so the zero Tag is the X of UniversalTags.
+type Tag struct {
+	Set   TagSetID
+	Index uint16
+}
+
+// RelationSetID identifies a registered RelationSet. The zero RelationSetID is UniversalRelations.
+type RelationSetID byte
+
+// RelationSet is a named inventory of dependency relations, such as the Universal Dependencies relations (UniversalRelations)
+// or the Stanford Dependencies (StanfordRelations). Every relation of a RelationSet maps to a coarse DependencyType.
+type RelationSet struct {
+	ID   RelationSetID
+	Name string
+
+	inventory
+	coarse []DependencyType
+}
+
+// Relation is a dependency relation of a RelationSet. The first relation of every RelationSet is its null relation,
+// so the zero Relation is the NoDepType of UniversalRelations.
+type Relation struct {
+	Set   RelationSetID
+	Index uint16
+}
+
+var (
+	inventoriesLock sync.RWMutex
+	tagSets         []*TagSet
+	relationSets    []*RelationSet
+)
+
+// RegisterTagSet registers a new TagSet. tags are the names of the tags, and coarse the POSTag of each tag. The first tag is the null tag.
+// The tags are also found by their lower cased names. The name of the TagSet has to be unique.
+func RegisterTagSet(name string, tags []string, coarse []POSTag) (*TagSet, error) {
+	if len(tags) != len(coarse) {
+		return nil, errors.Errorf("TagSet %q: expected a POSTag for each of the %d tags. Got %d", name, len(tags), len(coarse))
+	}
+	inv, err := newInventory(tags)
+	if err != nil {
+		return nil, errors.Wrapf(err, "TagSet %q", name)
+	}
+
+	inventoriesLock.Lock()
+	defer inventoriesLock.Unlock()
+	if len(tagSets) > 255 {
+		return nil, errors.Errorf("TagSet %q: too many TagSets", name)
+	}
+	for _, ts := range tagSets {
+		if ts.Name == name {
+			return nil, errors.Errorf("TagSet %q is already registered", name)
+		}
+	}
+	ts := &TagSet{
+		ID:        TagSetID(len(tagSets)),
+		Name:      name,
+		inventory: inv,
+		coarse:    append([]POSTag(nil), coarse...),
+	}
+	tagSets = append(tagSets, ts)
+	return ts, nil
+}
+
+// RegisterRelationSet registers a new RelationSet. relations are the names of the relations, and coarse the DependencyType of each relation.
+// The first relation is the null relation. The name of the RelationSet has to be unique.
+func RegisterRelationSet(name string, relations []string, coarse []DependencyType) (*RelationSet, error) {
+	if len(relations) != len(coarse) {
+		return nil, errors.Errorf("RelationSet %q: expected a DependencyType for each of the %d relations. Got %d", name, len(relations), len(coarse))
+	}
+	inv, err := newInventory(relations)
+	if err != nil {
+		return nil, errors.Wrapf(err, "RelationSet %q", name)
+	}
+
+	inventoriesLock.Lock()
+	defer inventoriesLock.Unlock()
+	if len(relationSets) > 255 {
+		return nil, errors.Errorf("RelationSet %q: too many RelationSets", name)
+	}
+	for _, rs := range relationSets {
+		if rs.Name == name {
+			return nil, errors.Errorf("RelationSet %q is already registered", name)
+		}
+	}
+	rs := &RelationSet{
+		ID:        RelationSetID(len(relationSets)),
+		Name:      name,
+		inventory: inv,
+		coarse:    append([]DependencyType(nil), coarse...),
+	}
+	relationSets = append(relationSets, rs)
+	return rs, nil
+}
+
+// GetTagSet returns the registered TagSet with the name.
+func GetTagSet(name string) (*TagSet, bool) {
+	inventoriesLock.RLock()
+	defer inventoriesLock.RUnlock()
+	for _, ts := range tagSets {
+		if ts.Name == name {
+			return ts, true
+		}
+	}
+	return nil, false
+}
+
+// GetRelationSet returns the registered RelationSet with the name.
+func GetRelationSet(name string) (*RelationSet, bool) {
+	inventoriesLock.RLock()
+	defer inventoriesLock.RUnlock()
+	for _, rs := range relationSets {
+		if rs.Name == name {
+			return rs, true
+		}
+	}
+	return nil, false
+}
+
+func tagSetByID(id TagSetID) *TagSet {
+	inventoriesLock.RLock()
+	defer inventoriesLock.RUnlock()
+	if int(id) < len(tagSets) {
+		return tagSets[id]
+	}
+	return nil
+}
+
+func relationSetByID(id RelationSetID) *RelationSet {
+	inventoriesLock.RLock()
+	defer inventoriesLock.RUnlock()
+	if int(id) < len(relationSets) {
+		return relationSets[id]
+	}
+	return nil
+}
+
+/* TagSet */
+
+// Tag returns the Tag with the name (or an alias of the name).
+func (ts *TagSet) Tag(name string) (Tag, bool) {
+	i, ok := ts.index(name)
+	return Tag{ts.ID, i}, ok
+}
+
+// Alias adds another name for a tag (e.g. PRP$ is also written as PPRP). Aliases should be added before the TagSet is used.
+func (ts *TagSet) Alias(alias string, t Tag) {
+	if t.Set == ts.ID && int(t.Index) < len(ts.names) {
+		ts.alias(alias, t.Index)
+	}
+}
+
+// Tags returns all the tags of the TagSet
+func (ts *TagSet) Tags() []Tag {
+	retVal := make([]Tag, len(ts.names))
+	for i := range ts.names {
+		retVal[i] = Tag{ts.ID, uint16(i)}
+	}
+	return retVal
+}
+
+// FromPOSTag returns the first tag whose coarse POSTag is the POSTag. Tags whose names start with "-" (e.g. -ROOT-) are only returned if there is no other.
+func (ts *TagSet) FromPOSTag(p POSTag) (Tag, bool) {
+	found := -1
+	for i, c := range ts.coarse {
+		if c != p {
+			continue
+		}
+		if !strings.HasPrefix(ts.names[i], "-") {
+			return Tag{ts.ID, uint16(i)}, true
+		}
+		if found < 0 {
+			found = i
+		}
+	}
+	if found < 0 {
+		return Tag{Set: ts.ID}, false
+	}
+	return Tag{ts.ID, uint16(found)}, true
+}
+
+func (ts *TagSet) String() string { return ts.Name }
+
+/* Tag */
+
+// TagSet returns the TagSet of the tag, or nil if it isn't registered.
+func (t Tag) TagSet() *TagSet { return tagSetByID(t.Set) }
+
+// POSTag returns the coarse POSTag of the tag. Tags that aren't in a registered TagSet are X.
+func (t Tag) POSTag() POSTag {
+	if ts := t.TagSet(); ts != nil && int(t.Index) < len(ts.coarse) {
+		return ts.coarse[t.Index]
+	}
+	return X
+}
+
+// String returns the name of the tag
+func (t Tag) String() string {
+	if ts := t.TagSet(); ts != nil {
+		if name, ok := ts.name(t.Index); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("Tag(%d, %d)", t.Set, t.Index)
+}
+
+// MarshalText marshals the tag as the name of its TagSet and its name, e.g. penn:NN
+func (t Tag) MarshalText() ([]byte, error) {
+	ts := t.TagSet()
+	if ts == nil {
+		return nil, errors.Errorf("TagSet %d is not registered", t.Set)
+	}
+	return []byte(ts.Name + ":" + t.String()), nil
+}
+
+func (t *Tag) UnmarshalText(text []byte) error {
+	parts := strings.SplitN(strings.Trim(string(text), `"`), ":", 2)
+	if len(parts) != 2 {
+		return errors.Errorf("Expected a tag like penn:NN. Got %q", text)
+	}
+	ts, ok := GetTagSet(parts[0])
+	if !ok {
+		return errors.Errorf("Unknown TagSet %q", parts[0])
+	}
+	tag, ok := ts.Tag(parts[1])
+	if !ok {
+		return errors.Errorf("Unknown tag %q in TagSet %q", parts[1], parts[0])
+	}
+	*t = tag
+	return nil
+}
+
+/* RelationSet */
+
+// Relation returns the Relation with the name (or an alias of the name). A relation with a subtype that isn't in the RelationSet
+// (e.g. the language specific obl:dengan) is the relation without its subtype (obl).
+func (rs *RelationSet) Relation(name string) (Relation, bool) {
+	i, ok := rs.index(name)
+	if !ok {
+		if parts := strings.SplitN(name, ":", 2); len(parts) == 2 {
+			i, ok = rs.index(parts[0])
+		}
+	}
+	return Relation{rs.ID, i}, ok
+}
+
+// Alias adds another name for a relation. Aliases should be added before the RelationSet is used.
+func (rs *RelationSet) Alias(alias string, r Relation) {
+	if r.Set == rs.ID && int(r.Index) < len(rs.names) {
+		rs.alias(alias, r.Index)
+	}
+}
+
+// Relations returns all the relations of the RelationSet
+func (rs *RelationSet) Relations() []Relation {
+	retVal := make([]Relation, len(rs.names))
+	for i := range rs.names {
+		retVal[i] = Relation{rs.ID, uint16(i)}
+	}
+	return retVal
+}
+
+// FromDependencyType returns the first relation whose coarse DependencyType is the DependencyType. Relations whose names start with "-" (e.g. -NULL-) are only returned if there is no other.
+func (rs *RelationSet) FromDependencyType(dt DependencyType) (Relation, bool) {
+	found := -1
+	for i, c := range rs.coarse {
+		if c != dt {
+			continue
+		}
+		if !strings.HasPrefix(rs.names[i], "-") {
+			return Relation{rs.ID, uint16(i)}, true
+		}
+		if found < 0 {
+			found = i
+		}
+	}
+	if found < 0 {
+		return Relation{Set: rs.ID}, false
+	}
+	return Relation{rs.ID, uint16(found)}, true
+}
+
+func (rs *RelationSet) String() string { return rs.Name }
+
+/* Relation */
+
+// RelationSet returns the RelationSet of the relation, or nil if it isn't registered.
+func (r Relation) RelationSet() *RelationSet { return relationSetByID(r.Set) }
+
+// DependencyType returns the coarse DependencyType of the relation. Relations that aren't in a registered RelationSet are NoDepType.
+func (r Relation) DependencyType() DependencyType {
+	if rs := r.RelationSet(); rs != nil && int(r.Index) < len(rs.coarse) {
+		return rs.coarse[r.Index]
+	}
+	return NoDepType
+}
+
+// String returns the name of the relation
+func (r Relation) String() string {
+	if rs := r.RelationSet(); rs != nil {
+		if name, ok := rs.name(r.Index); ok {
+			return name
+		}
+	}
+	return fmt.Sprintf("Relation(%d, %d)", r.Set, r.Index)
+}
+
+// MarshalText marshals the relation as the name of its RelationSet and its name, e.g. universal:nsubj
+func (r Relation) MarshalText() ([]byte, error) {
+	rs := r.RelationSet()
+	if rs == nil {
+		return nil, errors.Errorf("RelationSet %d is not registered", r.Set)
+	}
+	return []byte(rs.Name + ":" + r.String()), nil
+}
+
+func (r *Relation) UnmarshalText(text []byte) error {
+	parts := strings.SplitN(strings.Trim(string(text), `"`), ":", 2)
+	if len(parts) != 2 {
+		return errors.Errorf("Expected a relation like universal:nsubj. Got %q", text)
+	}
+	rs, ok := GetRelationSet(parts[0])
+	if !ok {
+		return errors.Errorf("Unknown RelationSet %q", parts[0])
+	}
+	rel, ok := rs.Relation(parts[1])
+	if !ok {
+		return errors.Errorf("Unknown relation %q in RelationSet %q", parts[1], parts[0])
+	}
+	*r = rel
+	return nil
+}
@@ -0,0 +1,118 @@
+package lingua
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestUniversalInventories(t *testing.T) {
+	if UniversalTags.ID != 0 || UniversalRelations.ID != 0 {
+		t.Fatalf("Expected the universal inventories to be the first. Got %d and %d", UniversalTags.ID, UniversalRelations.ID)
+	}
+
+	for p := X; p < MAXTAG; p++ {
+		tag, ok := UniversalTags.Tag(p.String())
+		if !ok || tag.POSTag() != p {
+			t.Errorf("Expected %v to be found in UniversalTags. Got %v", p, tag)
+		}
+	}
+	for dt := NoDepType; dt < MAXDEPTYPE; dt++ {
+		rel, ok := UniversalRelations.Relation(dt.String())
+		if !ok || rel.DependencyType() != dt {
+			t.Errorf("Expected %v to be found in UniversalRelations. Got %v", dt, rel)
+		}
+	}
+
+	if rel, _ := UniversalRelations.Relation("acl:relcl"); rel.DependencyType() != ACl_RelCl || rel.String() != "acl:relcl" {
+		t.Errorf("Unexpected relation %v", rel)
+	}
+	if (Tag{}).POSTag() != X || (Relation{}).DependencyType() != NoDepType {
+		t.Error("Expected the zero Tag and Relation to be null")
+	}
+
+	// POSTags and DependencyTypes are still unmarshalled by their names
+	var p POSTag
+	var dt DependencyType
+	if p.UnmarshalText([]byte("PROPN")); p != PROPN {
+		t.Errorf("Expected PROPN. Got %v", p)
+	}
+	if dt.UnmarshalText([]byte("Coordination")); dt != Coordination {
+		t.Errorf("Expected Coordination. Got %v", dt)
+	}
+}
+
+func TestPennTags(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  POSTag
+	}{
+		{"NNS", NOUN},
+		{"PRP$", PRON},
+		{"PPRP", PRON},
+		{"vbd", VERB},
+		{"-LRB-", PUNCT},
+	}
+	for _, tt := range tests {
+		tag, ok := PennTags.Tag(tt.name)
+		if !ok || tag.POSTag() != tt.tag || tag.Set != PennTags.ID {
+			t.Errorf("Expected %q to be a %v. Got %v (%v)", tt.name, tt.tag, tag, tag.POSTag())
+		}
+	}
+	if _, ok := PennTags.Tag("PROPN"); ok {
+		t.Error("Expected PROPN not to be a Penn tag")
+	}
+	if tag, _ := PennTags.FromPOSTag(NOUN); tag.String() != "NN" {
+		t.Errorf("Expected NN. Got %v", tag)
+	}
+
+	// tags of different tagsets in the same program
+	nn, _ := PennTags.Tag("NN")
+	noun, _ := UniversalTags.Tag("NOUN")
+	if nn == noun || nn.POSTag() != noun.POSTag() {
+		t.Errorf("Expected %v and %v to be different tags with the same POSTag", nn, noun)
+	}
+
+	b, err := json.Marshal([]Tag{nn, noun})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `["penn:NN","universal:NOUN"]` {
+		t.Errorf("Unexpected JSON %s", b)
+	}
+	var tags []Tag
+	if err = json.Unmarshal(b, &tags); err != nil {
+		t.Fatal(err)
+	}
+	if tags[0] != nn || tags[1] != noun {
+		t.Errorf("Expected %v. Got %v", []Tag{nn, noun}, tags)
+	}
+	if err = json.Unmarshal([]byte(`["klingon:NN"]`), &tags); err == nil {
+		t.Error("Expected an error for an unknown TagSet")
+	}
+}
+
+func TestRegisterTagSet(t *testing.T) {
+	ts, err := RegisterTagSet("test-morphind", []string{"-NULL-", "NSD", "VSA", "Z--"}, []POSTag{X, NOUN, VERB, PUNCT})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, ok := GetTagSet("test-morphind"); !ok || got != ts {
+		t.Error("Expected the TagSet to be registered")
+	}
+	if tag, _ := ts.Tag("VSA"); tag.POSTag() != VERB || tag.TagSet() != ts {
+		t.Errorf("Unexpected tag %v", tag)
+	}
+
+	if _, err = RegisterTagSet("test-morphind", []string{"-NULL-"}, []POSTag{X}); err == nil {
+		t.Error("Expected an error registering a TagSet twice")
+	}
+	if _, err = RegisterTagSet("test-bad", []string{"A", "A"}, []POSTag{X, X}); err == nil {
+		t.Error("Expected an error for repeated tags")
+	}
+	if _, err = RegisterTagSet("test-bad", []string{"A"}, nil); err == nil {
+		t.Error("Expected an error for missing POSTags")
+	}
+	if _, err = RegisterRelationSet("stanford", []string{"-NULL-"}, []DependencyType{NoDepType}); err == nil {
+		t.Error("Expected an error registering a RelationSet twice")
+	}
+}
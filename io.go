@@ -19,11 +19,22 @@ type dummyAnnotation struct {
 	Lemma string `json:"Lemma"`
 	Stem  string `json:"Stem"`
 
+	Features Features `json:"Features"`
+
 	Cluster  `json:"Cluster"`
 	Shape    `json:"Shape"`
 	WordFlag `json:"WordFlag"`
+
+	Start     int `json:"Start"`
+	End       int `json:"End"`
+	RuneStart int `json:"RuneStart"`
+	RuneEnd   int `json:"RuneEnd"`
+
+	SpaceAfter string `json:"SpaceAfter"`
 }
 
+func (d dummyAnnotation) span() Span { return Span{d.Start, d.End, d.RuneStart, d.RuneEnd} }
+
 // func (a *Annotation) MarshalText() ([]byte, error) {
 // 	var buf bytes.Buffer
 // 	if a.Head != nil {
@@ -63,6 +74,10 @@ func (a *Annotation) MarshalJSON() ([]byte, error) {
 		fmt.Fprintf(&buf, ",\"Stem\": %q", a.Stem)
 	}
 
+	if len(a.Features) > 0 {
+		fmt.Fprintf(&buf, ",\"Features\": %q", a.Features)
+	}
+
 	if a.Cluster > 0 {
 		fmt.Fprintf(&buf, ",\"Cluster\": %d", a.Cluster)
 	}
@@ -74,6 +89,14 @@ func (a *Annotation) MarshalJSON() ([]byte, error) {
 	if a.WordFlag > 0 {
 		fmt.Fprintf(&buf, ",\"WordFlag\": %d", a.WordFlag)
 	}
+
+	if a.SpaceAfter != "" {
+		fmt.Fprintf(&buf, ",\"SpaceAfter\": %q", a.SpaceAfter)
+	}
+
+	if !a.Span.IsZero() {
+		fmt.Fprintf(&buf, ",\"Start\": %d,\"End\": %d,\"RuneStart\": %d,\"RuneEnd\": %d", a.Span.Start, a.Span.End, a.Span.RuneStart, a.Span.RuneEnd)
+	}
 	buf.WriteRune('}')
 	return buf.Bytes(), nil
 }
@@ -95,9 +118,12 @@ func (a *Annotation) UnmarshalJSON(b []byte) error {
 	a.ID = d.ID
 	a.Lemma = d.Lemma
 	a.Stem = d.Stem
+	a.Features = d.Features
 	a.Cluster = d.Cluster
 	a.Shape = d.Shape
 	a.WordFlag = d.WordFlag
+	a.Span = d.span()
+	a.SpaceAfter = d.SpaceAfter
 
 	return nil
 }
@@ -150,9 +176,12 @@ func (as *AnnotatedSentence) UnmarshalJSON(b []byte) error {
 		a.ID = d.ID
 		a.Lemma = d.Lemma
 		a.Stem = d.Stem
+		a.Features = d.Features
 		a.Cluster = d.Cluster
 		a.Shape = d.Shape
 		a.WordFlag = d.WordFlag
+		a.Span = d.span()
+		a.SpaceAfter = d.SpaceAfter
 
 		(*as)[i] = a
 	}
@@ -160,6 +189,9 @@ func (as *AnnotatedSentence) UnmarshalJSON(b []byte) error {
 	// fix up head IDs
 	for i, d := range dummies {
 		a := (*as)[i]
+		if a == rootAnnotation {
+			continue // the root annotation is shared, and has no head
+		}
 		head := d.Head
 		if head == -1000 {
 			a.SetHead(rootAnnotation)
@@ -170,7 +202,9 @@ func (as *AnnotatedSentence) UnmarshalJSON(b []byte) error {
 
 	// TODO: fix up other things
 	for _, a := range *as {
-		a.Lowered = strings.ToLower(a.Value)
+		if a != rootAnnotation {
+			a.Lowered = strings.ToLower(a.Value)
+		}
 	}
 
 	return nil
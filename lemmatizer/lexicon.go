@@ -0,0 +1,140 @@
+// package lemmatizer provides lemmatizers that implement lingua.Lemmatizer
+package lemmatizer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+)
+
+var empty struct{}
+
+type entry struct {
+	form string
+	tag  lingua.POSTag
+}
+
+// Lexicon is a lemmatizer backed by a table of word forms, POSTags and lemmas.
+//
+// A word is first looked up with its POSTag. If it isn't found and the rules allow it, a reduplicated word is lemmatized by its base form.
+// Otherwise the Rule for the class of the POSTag (as given by lingua.IsNoun, lingua.IsVerb, lingua.IsAdjective and lingua.IsAdverb) is applied. If no rule applies, the lemmas of the word
+// regardless of POSTag are returned. Failing all that, the word itself is its lemma.
+type Lexicon struct {
+	table map[entry][]string
+	forms map[string][]string // lemmas regardless of POSTag
+	known map[string]struct{} // all forms and lemmas
+
+	rules Rules
+}
+
+// New creates a new empty *Lexicon that falls back to the given rules
+func New(rules Rules) *Lexicon {
+	return &Lexicon{
+		table: make(map[entry][]string),
+		forms: make(map[string][]string),
+		known: make(map[string]struct{}),
+		rules: rules,
+	}
+}
+
+// Add adds a form, its POSTag and its lemma to the lexicon.
+func (l *Lexicon) Add(form string, tag lingua.POSTag, lemma string) {
+	form = strings.ToLower(form)
+	e := entry{form, tag}
+	if !lingua.InStringSlice(lemma, l.table[e]) {
+		l.table[e] = append(l.table[e], lemma)
+	}
+	if !lingua.InStringSlice(lemma, l.forms[form]) {
+		l.forms[form] = append(l.forms[form], lemma)
+	}
+	l.known[form] = empty
+	l.known[lemma] = empty
+}
+
+// Known returns true if the word is a known form or lemma.
+func (l *Lexicon) Known(word string) bool {
+	_, ok := l.known[word]
+	return ok
+}
+
+// Read reads a tab separated table of form, POSTag and lemma, one entry per line. Empty lines and lines starting with # are skipped.
+// The POSTags are read with the same names as their String() representations (e.g. NOUN, VERB).
+func (l *Lexicon) Read(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for lineNo := 0; s.Scan(); lineNo++ {
+		line := s.Text()
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) != 3 {
+			return errors.Errorf("Line %d: expected 3 columns. Got %d instead", lineNo+1, len(cols))
+		}
+		t, ok := lingua.UniversalTags.Tag(cols[1])
+		if !ok {
+			return errors.Errorf("Line %d: unknown POSTag %q", lineNo+1, cols[1])
+		}
+		tag := t.POSTag()
+		l.Add(cols[0], tag, cols[2])
+	}
+	return s.Err()
+}
+
+// ReadConllu harvests the FORM, UPOS and LEMMA columns of a file formatted in a CONLLU format.
+// Comments, multiword token ranges, empty nodes and underscores in the LEMMA column are skipped.
+func (l *Lexicon) ReadConllu(r io.Reader) error {
+	s := bufio.NewScanner(r)
+	for lineNo := 0; s.Scan(); lineNo++ {
+		line := s.Text()
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		cols := strings.Split(line, "\t")
+		if len(cols) < 4 {
+			return errors.Errorf("Line %d: expected at least 4 columns. Got %d instead", lineNo+1, len(cols))
+		}
+		if strings.ContainsAny(cols[0], "-.") || cols[2] == "_" {
+			continue
+		}
+
+		t, ok := lingua.UniversalTags.Tag(cols[3])
+		if !ok {
+			return errors.Errorf("Line %d: unknown POSTag %q", lineNo+1, cols[3])
+		}
+		tag := t.POSTag()
+		l.Add(lingua.UnescapeSpecials(cols[1]), tag, strings.ToLower(cols[2]))
+	}
+	return s.Err()
+}
+
+// Lemmatize returns the lemmas of a word, given its POSTag. The first lemma is the most likely one.
+func (l *Lexicon) Lemmatize(word string, tag lingua.POSTag) ([]string, error) {
+	w := strings.ToLower(word)
+	if lemmas, ok := l.table[entry{w, tag}]; ok {
+		return lemmas, nil
+	}
+
+	if l.rules.Reduplicated {
+		if base, r := lingua.Reduplicated(w); r != lingua.NotReduplicated {
+			return l.Lemmatize(base, tag)
+		}
+	}
+
+	if tag != lingua.X && tag != lingua.UNKNOWN_TAG {
+		if rule := l.rules.For(tag); rule != nil {
+			if lemmas := rule(w, l.Known); len(lemmas) > 0 {
+				return lemmas, nil
+			}
+		}
+	}
+
+	if lemmas, ok := l.forms[w]; ok {
+		return lemmas, nil
+	}
+	return []string{w}, nil
+}
@@ -0,0 +1,188 @@
+package lemmatizer
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+)
+
+var _ lingua.Lemmatizer = &Lexicon{}
+
+const indonesianConllu = `# sent_id = test-s1
+# text = Gedung itu berdirinya di pusat kota.
+1	Gedung	gedung	PROPN	NSD	_	3	nsubj	_	_
+2	itu	itu	DET	B--	_	1	det	_	_
+3-4	berdirinya	_	_	_	_	_	_	_	_
+3	berdiri	berdiri	VERB	VSA	_	0	root	_	_
+4	nya	dia	PRON	PS3	_	3	nmod	_	_
+5	di	di	ADP	R--	_	6	case	_	_
+6	pusat	pusat	NOUN	NSD	_	3	obl	_	_
+7	kota	kota	NOUN	NSD	_	6	compound	_	_
+8	.	.	PUNCT	Z--	_	3	punct	_	_
+
+# sent_id = test-s2
+1	Bukunya	buku	NOUN	NSD	_	0	root	_	_
+2	.	.	PUNCT	Z--	_	1	punct	_	_
+`
+
+const englishTable = `# form	POS	lemma
+saw	VERB	see
+saw	NOUN	saw
+geese	NOUN	goose
+`
+
+func TestLexicon_Read(t *testing.T) {
+	l := New(EnglishRules)
+	if err := l.Read(strings.NewReader(englishTable)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		word   string
+		tag    lingua.POSTag
+		lemmas []string
+	}{
+		{"saw", lingua.VERB, []string{"see"}},
+		{"saw", lingua.NOUN, []string{"saw"}},
+		{"Geese", lingua.NOUN, []string{"goose"}},
+		{"saw", lingua.X, []string{"see", "saw"}},
+	}
+	for _, tt := range tests {
+		lemmas, err := l.Lemmatize(tt.word, tt.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Join(lemmas, " ") != strings.Join(tt.lemmas, " ") {
+			t.Errorf("Expected %q/%v to be lemmatized to %v. Got %v instead", tt.word, tt.tag, tt.lemmas, lemmas)
+		}
+	}
+
+	if err := l.Read(strings.NewReader("saw\tVERB")); err == nil {
+		t.Error("Expected an error when a line has the wrong number of columns")
+	}
+	if err := l.Read(strings.NewReader("saw\tVREB\tsee")); err == nil || !strings.Contains(err.Error(), "Line 1") {
+		t.Errorf("Expected an error on line 1 for an unknown POSTag. Got %v", err)
+	}
+	if err := l.Read(strings.NewReader("saw\tVERB\t" + strings.Repeat("e", 64*1024) + "\n")); err != bufio.ErrTooLong {
+		t.Errorf("Expected bufio.ErrTooLong for a line that is too long. Got %v", err)
+	}
+}
+
+func TestLexicon_ReadConllu(t *testing.T) {
+	l := New(IndonesianRules)
+	if err := l.ReadConllu(strings.NewReader(indonesianConllu)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		word  string
+		tag   lingua.POSTag
+		lemma string
+	}{
+		{"berdiri", lingua.VERB, "berdiri"},
+		{"berdirinya", lingua.VERB, "berdiri"}, // the multiword token isn't in the lexicon, but its clitic can be stripped
+		{"Bukunya", lingua.NOUN, "buku"},
+		{"kotamu", lingua.NOUN, "kota"},
+		{"pusatlah", lingua.NOUN, "pusat"},
+		{"sekolah", lingua.NOUN, "sekolah"}, // "seko" isn't known, so -lah isn't a particle
+		{"dia", lingua.PRON, "dia"},
+	}
+	for _, tt := range tests {
+		lemmas, err := l.Lemmatize(tt.word, tt.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lemmas[0] != tt.lemma {
+			t.Errorf("Expected %q/%v to be lemmatized to %q. Got %v instead", tt.word, tt.tag, tt.lemma, lemmas)
+		}
+	}
+
+	if err := l.ReadConllu(strings.NewReader("# sent_id = 1\n1\tbuku\tbuku\tNOUNX\t_\t_\t0\troot\t_\t_\n")); err == nil || !strings.Contains(err.Error(), "Line 2") {
+		t.Errorf("Expected an error on line 2 for an unknown UPOS. Got %v", err)
+	}
+}
+
+func TestEnglishRules(t *testing.T) {
+	l := New(EnglishRules)
+	tests := []struct {
+		word  string
+		tag   lingua.POSTag
+		lemma string
+	}{
+		{"cats", lingua.NOUN, "cat"},
+		{"studies", lingua.NOUN, "study"},
+		{"boxes", lingua.NOUN, "box"},
+		{"class", lingua.NOUN, "class"},
+		{"news", lingua.NOUN, "news"},
+		{"running", lingua.VERB, "run"},
+		{"making", lingua.VERB, "make"},
+		{"relating", lingua.VERB, "relate"},
+		{"treating", lingua.VERB, "treat"},
+		{"walked", lingua.VERB, "walk"},
+		{"used", lingua.VERB, "use"},
+		{"studied", lingua.VERB, "study"},
+		{"watches", lingua.VERB, "watch"},
+		{"runs", lingua.VERB, "run"},
+		{"went", lingua.VERB, "go"},
+		{"bigger", lingua.ADJ, "big"},
+		{"happiest", lingua.ADJ, "happy"},
+		{"better", lingua.ADJ, "good"},
+		{"running", lingua.X, "running"},
+	}
+	for _, tt := range tests {
+		lemmas, err := l.Lemmatize(tt.word, tt.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lemmas[0] != tt.lemma {
+			t.Errorf("Expected %q/%v to be lemmatized to %q. Got %v instead", tt.word, tt.tag, tt.lemma, lemmas)
+		}
+	}
+}
+
+func TestAnnotation_Process(t *testing.T) {
+	l := New(IndonesianRules)
+	if err := l.ReadConllu(strings.NewReader(indonesianConllu)); err != nil {
+		t.Fatal(err)
+	}
+
+	a := lingua.AnnotationFromLexTag(lingua.MakeLexeme("Bukunya", lingua.Word), lingua.NOUN, lingua.NewFixer(lingua.WithLemmatizer(l)))
+	if a.Lemma != "buku" {
+		t.Errorf("Expected the Lemma to be %q. Got %q instead", "buku", a.Lemma)
+	}
+}
+
+func TestLexicon_Reduplicated(t *testing.T) {
+	l := New(IndonesianRules)
+	if err := l.ReadConllu(strings.NewReader(indonesianConllu)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		word  string
+		tag   lingua.POSTag
+		lemma string
+	}{
+		{"gedung-gedung", lingua.NOUN, "gedung"},
+		{"kota2", lingua.NOUN, "kota"},
+		{"buku-bukunya", lingua.NOUN, "buku"},
+		{"berdiri-diri", lingua.VERB, "berdiri"},
+	}
+	for _, tt := range tests {
+		lemmas, err := l.Lemmatize(tt.word, tt.tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lemmas[0] != tt.lemma {
+			t.Errorf("Expected %q/%v to be lemmatized to %q. Got %v instead", tt.word, tt.tag, tt.lemma, lemmas)
+		}
+	}
+
+	// English rules don't undo reduplications
+	lemmas, _ := New(EnglishRules).Lemmatize("hip-hop", lingua.NOUN)
+	if lemmas[0] != "hip-hop" {
+		t.Errorf("Expected hip-hop to be left alone. Got %v instead", lemmas)
+	}
+}
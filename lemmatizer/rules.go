@@ -0,0 +1,237 @@
+package lemmatizer
+
+import (
+	"strings"
+
+	"github.com/sapariduo/lingua"
+	"github.com/sapariduo/lingua/corpus"
+)
+
+// Rule lemmatizes a lower cased word. It returns nil if the rule does not apply.
+// known reports whether a form is in the lexicon, which a rule may use to check its guesses.
+type Rule func(word string, known func(string) bool) []string
+
+// Rules are the rules for each class of POSTags. A nil Rule means there are no rules for the class.
+type Rules struct {
+	Noun      Rule
+	Verb      Rule
+	Adjective Rule
+	Adverb    Rule
+
+	// Reduplicated words are lemmatized by their base form (see lingua.Reduplicated): anak-anak -> anak
+	Reduplicated bool
+}
+
+// For returns the Rule for the class of the POSTag.
+func (r Rules) For(tag lingua.POSTag) Rule {
+	switch {
+	case lingua.IsNoun(tag):
+		return r.Noun
+	case lingua.IsVerb(tag):
+		return r.Verb
+	case lingua.IsAdjective(tag):
+		return r.Adjective
+	case lingua.IsAdverb(tag):
+		return r.Adverb
+	}
+	return nil
+}
+
+// IndonesianRules follow the Indonesian UD treebanks, where a lemma keeps its derivational affixes but loses its clitics:
+// berdirinya -> berdiri, rumahku -> rumah, dimakannya -> dimakan. Reduplicated words are lemmatized by their base form.
+var IndonesianRules = Rules{
+	Noun:      StripClitics,
+	Verb:      StripClitics,
+	Adjective: StripClitics,
+	Adverb:    StripClitics,
+
+	Reduplicated: true,
+}
+
+// EnglishRules undo the English inflections: plural nouns, verb tenses and comparative adjectives.
+var EnglishRules = Rules{
+	Noun:      EnglishNoun,
+	Verb:      EnglishVerb,
+	Adjective: EnglishAdjective,
+}
+
+/* Indonesian */
+
+var (
+	particles   = []string{"lah", "kah", "tah", "pun"}
+	possessives = []string{"nya", "ku", "mu"}
+)
+
+// StripClitics removes the Indonesian particles (-lah, -kah, -tah, -pun) and possessive pronouns (-ku, -mu, -nya).
+// Because many root words end with the same letters (sekolah, buku, punya), the result must be a known form.
+func StripClitics(word string, known func(string) bool) []string {
+	candidates := []string{word}
+	for _, suffixes := range [][]string{particles, possessives} {
+		for _, c := range candidates {
+			for _, suf := range suffixes {
+				if strings.HasSuffix(c, suf) && len(c)-len(suf) >= 2 {
+					candidates = append(candidates, strings.TrimSuffix(c, suf))
+				}
+			}
+		}
+	}
+
+	// the shortest known form wins
+	for i := len(candidates) - 1; i > 0; i-- {
+		if known(candidates[i]) {
+			return []string{candidates[i]}
+		}
+	}
+	return nil
+}
+
+/* English */
+
+var englishIrregulars = map[string]string{
+	"am": "be", "are": "be", "is": "be", "was": "be", "were": "be", "been": "be", "being": "be",
+	"has": "have", "had": "have", "having": "have",
+	"does": "do", "did": "do", "done": "do",
+	"went": "go", "gone": "go", "goes": "go",
+	"made": "make", "said": "say", "got": "get", "gotten": "get",
+	"took": "take", "taken": "take", "came": "come", "saw": "see", "seen": "see",
+	"knew": "know", "known": "know", "thought": "think", "gave": "give", "given": "give",
+	"found": "find", "told": "tell", "became": "become", "left": "leave", "felt": "feel",
+	"brought": "bring", "began": "begin", "begun": "begin", "kept": "keep", "held": "hold",
+	"wrote": "write", "written": "write", "stood": "stand", "heard": "hear", "meant": "mean",
+	"met": "meet", "ran": "run", "paid": "pay", "sat": "sit", "spoke": "speak", "spoken": "speak",
+	"led": "lead", "grew": "grow", "grown": "grow", "lost": "lose", "fell": "fall", "fallen": "fall",
+	"sent": "send", "built": "build", "understood": "understand", "spent": "spend", "bought": "buy",
+	"caught": "catch", "taught": "teach", "sold": "sell", "won": "win", "ate": "eat", "eaten": "eat",
+	"drove": "drive", "driven": "drive", "broke": "break", "broken": "break", "chose": "choose", "chosen": "choose",
+	"wore": "wear", "worn": "wear", "flew": "fly", "flown": "fly", "drew": "draw", "drawn": "draw",
+	"threw": "throw", "thrown": "throw", "forgot": "forget", "forgotten": "forget",
+	"fought": "fight", "sought": "seek", "slept": "sleep", "dealt": "deal",
+}
+
+var englishIrregularAdjectives = map[string]string{
+	"better": "good", "best": "good", "worse": "bad", "worst": "bad",
+	"more": "much", "most": "much", "less": "little", "least": "little",
+	"further": "far", "furthest": "far", "farther": "far", "farthest": "far",
+}
+
+var englishUncountables = []string{"news", "series", "species", "means", "physics", "mathematics"}
+
+// EnglishNoun singularizes plural nouns.
+func EnglishNoun(word string, known func(string) bool) []string {
+	if !strings.HasSuffix(word, "s") || lingua.InStringSlice(word, englishUncountables) {
+		return nil
+	}
+	for _, suf := range []string{"ss", "us", "is"} {
+		if strings.HasSuffix(word, suf) {
+			return nil
+		}
+	}
+
+	if s := corpus.Singularize(word); s != word {
+		return []string{s}
+	}
+	return nil
+}
+
+// EnglishVerb removes the -s, -ed and -ing inflections of a verb.
+func EnglishVerb(word string, known func(string) bool) []string {
+	if lemma, ok := englishIrregulars[word]; ok {
+		return []string{lemma}
+	}
+
+	switch {
+	case strings.HasSuffix(word, "ies") || strings.HasSuffix(word, "ied"):
+		if len(word) > 4 {
+			return []string{word[:len(word)-3] + "y"}
+		}
+	case strings.HasSuffix(word, "ing") && len(word) > 4:
+		return []string{undoSuffix(word[:len(word)-3], known)}
+	case strings.HasSuffix(word, "ed") && len(word) > 3:
+		return []string{undoSuffix(word[:len(word)-2], known)}
+	case strings.HasSuffix(word, "es") && hasAnySuffix(word[:len(word)-2], "ch", "sh", "ss", "x", "z", "o"):
+		return []string{word[:len(word)-2]}
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") && len(word) > 2:
+		return []string{word[:len(word)-1]}
+	}
+	return nil
+}
+
+// EnglishAdjective handles the irregular comparatives and superlatives, those that end with -ier and -iest, and those with a doubled consonant (bigger, hottest)
+func EnglishAdjective(word string, known func(string) bool) []string {
+	if lemma, ok := englishIrregularAdjectives[word]; ok {
+		return []string{lemma}
+	}
+
+	for _, suf := range []string{"iest", "ier"} {
+		if strings.HasSuffix(word, suf) && len(word) > len(suf)+1 {
+			return []string{strings.TrimSuffix(word, suf) + "y"}
+		}
+	}
+	for _, suf := range []string{"est", "er"} {
+		if !strings.HasSuffix(word, suf) {
+			continue
+		}
+		stem := strings.TrimSuffix(word, suf)
+		if n := len(stem); n > 2 && stem[n-1] == stem[n-2] && !isEnglishVowel(stem[n-1]) && !hasAnySuffix(stem, "ll", "ss") {
+			return []string{stem[:n-1]}
+		}
+	}
+	return nil
+}
+
+// undoSuffix restores the root of a verb whose -ed or -ing has been removed:
+// a doubled consonant is undoubled (running -> run), and an e is restored to short words and words that need it (making -> make, relating -> relate).
+func undoSuffix(stem string, known func(string) bool) string {
+	if known(stem) {
+		return stem
+	}
+	if known(stem + "e") {
+		return stem + "e"
+	}
+
+	n := len(stem)
+	switch {
+	case n > 2 && stem[n-1] == stem[n-2] && !isEnglishVowel(stem[n-1]) && !hasAnySuffix(stem, "ll", "ss", "zz", "ff"):
+		return stem[:n-1]
+	case hasAnySuffix(stem, "bl", "iz", "v", "c"):
+		return stem + "e"
+	case strings.HasSuffix(stem, "at") && n > 3 && !isEnglishVowel(stem[n-3]):
+		return stem + "e" // relating -> relate, but not treating -> treate
+	case isShortEnglish(stem):
+		return stem + "e"
+	}
+	return stem
+}
+
+func isEnglishVowel(c byte) bool { return strings.IndexByte("aeiou", c) >= 0 }
+
+// isShortEnglish checks if the stem is a single syllable that ends with a consonant-vowel-consonant (or starts with a vowel-consonant),
+// where the last consonant isn't w, x or y
+func isShortEnglish(stem string) bool {
+	n := len(stem)
+	if n == 2 {
+		return isEnglishVowel(stem[0]) && !isEnglishVowel(stem[1])
+	}
+	if n < 3 || n > 4 {
+		return false
+	}
+	c := stem[n-1]
+	if isEnglishVowel(c) || c == 'w' || c == 'x' || c == 'y' || !isEnglishVowel(stem[n-2]) || isEnglishVowel(stem[n-3]) {
+		return false
+	}
+	for i := 0; i < n-2; i++ {
+		if isEnglishVowel(stem[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasAnySuffix(s string, suffixes ...string) bool {
+	for _, suf := range suffixes {
+		if strings.HasSuffix(s, suf) {
+			return true
+		}
+	}
+	return false
+}
@@ -29,8 +29,27 @@ type Lexeme struct {
 
 	Line int
 	Col  int
+
+	Span       Span   // where the Lexeme is in the text it was read from. The zero Span means the Lexeme wasn't read from a text
+	SpaceAfter string // the whitespace that follows the Lexeme. In CoNLL-U terms, an empty SpaceAfter is SpaceAfter=No
+}
+
+// Span is the position of a Lexeme in the text it was read from, as byte offsets and as rune offsets.
+// The starts are inclusive and the ends are exclusive, so text[Start:End] is the Lexeme.
+type Span struct {
+	Start, End         int
+	RuneStart, RuneEnd int
 }
 
+// IsZero returns true if the Span doesn't cover any text
+func (s Span) IsZero() bool { return s.End <= s.Start }
+
+// Contains checks if the byte offset is within the span
+func (s Span) Contains(offset int) bool { return offset >= s.Start && offset < s.End }
+
+// ContainsRune checks if the rune offset is within the span
+func (s Span) ContainsRune(offset int) bool { return offset >= s.RuneStart && offset < s.RuneEnd }
+
 func MakeLexeme(s string, t LexemeType) Lexeme {
 	return Lexeme{
 		Value:      s,
@@ -0,0 +1,518 @@
+package lingua
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Pattern is a compiled dependency pattern, in a small query language in the style of Semgrex. A pattern is a node, followed by its relations to other nodes:
+//		{tag:VERB;word:/^di/}=verb >obl:agent {}=agent
+//
+// A node is a set of attributes between braces, separated by ";". {} is any word. An attribute is a key and a value, a /regular expression/,
+// or either of them after "!" for the words that don't match it. These are the keys:
+//		word   the Value of the word
+//		lemma  the Lemma
+//		tag    the POSTag, as named in UniversalTags (e.g. NOUN)
+//		rel    the DependencyType of the word, as named in UniversalRelations (e.g. nsubj or obl:agent)
+//		shape  the Shape
+//		flag   a WordFlag the word has (e.g. IsTitle)
+//
+// A node followed by "=name" is bound to the name, and is returned in the Bindings of the Matches. A name that is used twice is the same word.
+//
+// These are the relations, from the node on the left to the node on the right:
+//		A > B    A is the head of B
+//		A >rel B A is the head of B, and B has the relation rel (or /regex/)
+//		A < B    A depends on B
+//		A <rel B A depends on B with the relation rel (or /regex/)
+//		A >> B   A is an ancestor of B
+//		A << B   A is a descendant of B
+//		A . B    A is immediately before B
+//		A .. B   A is somewhere before B
+//
+// A relation after "!" matches when there is no such B. All the relations of a node are from that node: A > B > C is A > B and A > C.
+// Parentheses group a node with its own relations: A > (B > C). Relations after a group are from its first node: (A > B) > C is A > B > C.
+type Pattern struct {
+	root *patternNode
+	src  string
+}
+
+type patternNode struct {
+	attrs []patternAttr
+	name  string
+	rels  []patternRel
+}
+
+type patternAttr struct {
+	key     string
+	value   string
+	re      *regexp.Regexp
+	negated bool
+	rel     DependencyType // the value of a rel attribute
+	flag    WordFlag       // the value of a flag attribute
+}
+
+type relOp byte
+
+const (
+	headOf relOp = iota
+	dependentOf
+	ancestorOf
+	descendantOf
+	justBefore
+	before
+)
+
+type patternRel struct {
+	op      relOp
+	label   *patternAttr // the relation of the dependent, for > and <
+	negated bool
+	node    *patternNode
+}
+
+var flagNames = map[string]WordFlag{
+	"IsLetter":       IsLetter,
+	"IsAscii":        IsAscii,
+	"IsDigit":        IsDigit,
+	"IsLower":        IsLower,
+	"IsPunct":        IsPunct,
+	"IsSpace":        IsSpace,
+	"IsTitle":        IsTitle,
+	"IsUpper":        IsUpper,
+	"LikeURL":        LikeURL,
+	"LikeNum":        LikeNum,
+	"LikeEmail":      LikeEmail,
+	"IsStopWord":     IsStopWord,
+	"IsOOV":          IsOOV,
+	"IsReduplicated": IsReduplicated,
+}
+
+// CompilePattern compiles a Pattern. See Pattern for the query language.
+func CompilePattern(s string) (*Pattern, error) {
+	p := &patternParser{src: []rune(s)}
+	root, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); p.pos < len(p.src) {
+		return nil, p.errorf("Unexpected %q", p.src[p.pos])
+	}
+	return &Pattern{root: root, src: s}, nil
+}
+
+// MustCompilePattern is CompilePattern, but panics if the pattern can't be compiled. It is meant for patterns in the code.
+func MustCompilePattern(s string) *Pattern {
+	p, err := CompilePattern(s)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func (p *Pattern) String() string { return p.src }
+
+type patternParser struct {
+	src []rune
+	pos int
+}
+
+func (p *patternParser) errorf(format string, args ...interface{}) error {
+	return errors.Wrapf(errors.Errorf(format, args...), "Pattern error at %d", p.pos)
+}
+
+func (p *patternParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(p.src[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *patternParser) peek() rune {
+	if p.pos < len(p.src) {
+		return p.src[p.pos]
+	}
+	return 0
+}
+
+func (p *patternParser) accept(s string) bool {
+	r := []rune(s)
+	if p.pos+len(r) > len(p.src) || string(p.src[p.pos:p.pos+len(r)]) != s {
+		return false
+	}
+	p.pos += len(r)
+	return true
+}
+
+// pattern parses a node and its relations
+func (p *patternParser) pattern() (*patternNode, error) {
+	p.skipSpace()
+	var n *patternNode
+	var err error
+	switch {
+	case p.accept("("):
+		if n, err = p.group(); err != nil {
+			return nil, err
+		}
+	case p.accept("{"):
+		if n, err = p.node(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, p.errorf("Expected a node")
+	}
+
+	for {
+		p.skipSpace()
+		if c := p.peek(); c == 0 || c == ')' {
+			return n, nil
+		}
+		rel, err := p.relation()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.accept("(") {
+			rel.node, err = p.group()
+		} else if p.accept("{") {
+			rel.node, err = p.node()
+		} else {
+			err = p.errorf("Expected a node")
+		}
+		if err != nil {
+			return nil, err
+		}
+		n.rels = append(n.rels, rel)
+	}
+}
+
+// group parses a pattern in parentheses, after its opening parenthesis. The relations after the group are the relations of its first node.
+func (p *patternParser) group() (*patternNode, error) {
+	n, err := p.pattern()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpace(); !p.accept(")") {
+		return nil, p.errorf("Expected )")
+	}
+	return n, nil
+}
+
+// node parses the attributes and the name of a node, after its opening brace
+func (p *patternParser) node() (*patternNode, error) {
+	n := new(patternNode)
+	for {
+		p.skipSpace()
+		if p.accept("}") {
+			break
+		}
+		if len(n.attrs) > 0 && !p.accept(";") {
+			return nil, p.errorf("Expected ; or }")
+		}
+		p.skipSpace()
+		key := p.identifier()
+		if p.skipSpace(); !p.accept(":") {
+			return nil, p.errorf("Expected : after %q", key)
+		}
+		p.skipSpace()
+		attr := patternAttr{key: key, negated: p.accept("!")}
+		if err := p.value(&attr, ";}"); err != nil {
+			return nil, err
+		}
+		if err := attr.compile(); err != nil {
+			return nil, p.errorf("%v", err)
+		}
+		n.attrs = append(n.attrs, attr)
+	}
+
+	if p.accept("=") {
+		if n.name = p.identifier(); n.name == "" {
+			return nil, p.errorf("Expected a name after =")
+		}
+	}
+	return n, nil
+}
+
+// relation parses the operator of a relation
+func (p *patternParser) relation() (patternRel, error) {
+	rel := patternRel{negated: p.accept("!")}
+	switch {
+	case p.accept(">>"):
+		rel.op = ancestorOf
+	case p.accept("<<"):
+		rel.op = descendantOf
+	case p.accept(".."):
+		rel.op = before
+	case p.accept("."):
+		rel.op = justBefore
+	case p.peek() == '>' || p.peek() == '<':
+		rel.op = headOf
+		if p.peek() == '<' {
+			rel.op = dependentOf
+		}
+		p.pos++
+		if c := p.peek(); c == 0 || unicode.IsSpace(c) || c == '{' || c == '(' {
+			break
+		}
+		label := &patternAttr{key: "rel"}
+		if err := p.value(label, " \t\n{("); err != nil {
+			return rel, err
+		}
+		if err := label.compile(); err != nil {
+			return rel, p.errorf("%v", err)
+		}
+		rel.label = label
+	default:
+		return rel, p.errorf("Expected a relation")
+	}
+	return rel, nil
+}
+
+// value parses a value, or a /regular expression/, up to one of the runes of end
+func (p *patternParser) value(attr *patternAttr, end string) error {
+	if !p.accept("/") {
+		start := p.pos
+		for p.pos < len(p.src) && !strings.ContainsRune(end, p.src[p.pos]) {
+			p.pos++
+		}
+		attr.value = strings.TrimSpace(string(p.src[start:p.pos]))
+		if attr.value == "" {
+			return p.errorf("Expected a value for %q", attr.key)
+		}
+		return nil
+	}
+
+	var buf strings.Builder
+	for {
+		if p.pos >= len(p.src) {
+			return p.errorf("Unterminated regular expression")
+		}
+		c := p.src[p.pos]
+		p.pos++
+		if c == '/' {
+			break
+		}
+		if c == '\\' && p.peek() == '/' {
+			c = '/'
+			p.pos++
+		}
+		buf.WriteRune(c)
+	}
+	re, err := regexp.Compile(buf.String())
+	if err != nil {
+		return p.errorf("%v", err)
+	}
+	attr.re = re
+	return nil
+}
+
+func (p *patternParser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.src) && (unicode.IsLetter(p.src[p.pos]) || unicode.IsDigit(p.src[p.pos]) || p.src[p.pos] == '_') {
+		p.pos++
+	}
+	return string(p.src[start:p.pos])
+}
+
+// compile checks the key, and looks up the value of the rel and flag attributes
+func (a *patternAttr) compile() error {
+	switch a.key {
+	case "word", "lemma", "tag", "shape":
+	case "rel":
+		if a.re == nil {
+			rel, ok := UniversalRelations.Relation(a.value)
+			if !ok || rel.DependencyType() == NoDepType {
+				return errors.Errorf("Unknown relation %q", a.value)
+			}
+			a.rel = rel.DependencyType()
+		}
+	case "flag":
+		if a.re != nil {
+			return errors.New("flag can't be a regular expression")
+		}
+		f, ok := flagNames[a.value]
+		if !ok {
+			return errors.Errorf("Unknown flag %q", a.value)
+		}
+		a.flag = f
+	default:
+		return errors.Errorf("Unknown attribute %q", a.key)
+	}
+	return nil
+}
+
+func (a *patternAttr) matches(w *Annotation) bool {
+	var ok bool
+	switch a.key {
+	case "rel":
+		if a.re == nil {
+			ok = w.DependencyType == a.rel
+		} else {
+			rel, _ := UniversalRelations.FromDependencyType(w.DependencyType)
+			ok = a.re.MatchString(rel.String())
+		}
+	case "flag":
+		ok = w.WordFlag&(1<<a.flag) != 0
+	default:
+		ok = a.matchString(a.attribute(w))
+	}
+	return ok != a.negated
+}
+
+func (a *patternAttr) attribute(w *Annotation) string {
+	switch a.key {
+	case "word":
+		return w.Value
+	case "lemma":
+		return w.Lemma
+	case "tag":
+		tag, _ := UniversalTags.FromPOSTag(w.POSTag)
+		return tag.String()
+	case "shape":
+		return string(w.Shape)
+	}
+	return ""
+}
+
+func (a *patternAttr) matchString(s string) bool {
+	if a.re != nil {
+		return a.re.MatchString(s)
+	}
+	return s == a.value
+}
+
+// Match is a match of a Pattern in a sentence
+type Match struct {
+	Node     *Annotation            // the word that the first node of the pattern matched
+	Bindings map[string]*Annotation // the words of the named nodes
+}
+
+// Match returns the Matches of the pattern in the sentence. The sentence starts with the root annotation, as in a *Dependency,
+// which is never matched. A word may be matched several times, with different Bindings.
+func (p *Pattern) Match(as AnnotatedSentence) []Match {
+	m := &patternMatcher{as: as}
+	var matches []Match
+	for i := 1; i < len(as); i++ {
+		m.node(p.root, i, nil, func(b bindings) {
+			match := Match{Node: as[i], Bindings: make(map[string]*Annotation, len(b))}
+			for name, j := range b {
+				match.Bindings[name] = as[j]
+			}
+			matches = append(matches, match)
+		})
+	}
+	return matches
+}
+
+// MatchDependency returns the Matches of the pattern in the dependency tree. See Match.
+func (p *Pattern) MatchDependency(d *Dependency) []Match { return p.Match(d.AnnotatedSentence) }
+
+// bindings are the words of the named nodes. They are copied when a name is bound, so that backtracking doesn't undo them.
+type bindings map[string]int
+
+func (b bindings) with(name string, i int) bindings {
+	retVal := make(bindings, len(b)+1)
+	for k, v := range b {
+		retVal[k] = v
+	}
+	retVal[name] = i
+	return retVal
+}
+
+type patternMatcher struct {
+	as AnnotatedSentence
+}
+
+// node calls k with the bindings of each way that the node and its relations match the ith word
+func (m *patternMatcher) node(n *patternNode, i int, b bindings, k func(bindings)) {
+	for _, a := range n.attrs {
+		if !a.matches(m.as[i]) {
+			return
+		}
+	}
+	if n.name != "" {
+		if j, ok := b[n.name]; ok && j != i {
+			return
+		} else if !ok {
+			b = b.with(n.name, i)
+		}
+	}
+	m.rels(n, 0, i, b, k)
+}
+
+func (m *patternMatcher) rels(n *patternNode, r, i int, b bindings, k func(bindings)) {
+	if r == len(n.rels) {
+		k(b)
+		return
+	}
+	rel := n.rels[r]
+	if !rel.negated {
+		for _, j := range m.related(rel, i) {
+			m.node(rel.node, j, b, func(b bindings) { m.rels(n, r+1, i, b, k) })
+		}
+		return
+	}
+
+	found := false
+	for _, j := range m.related(rel, i) {
+		m.node(rel.node, j, b, func(bindings) { found = true })
+		if found {
+			return
+		}
+	}
+	m.rels(n, r+1, i, b, k)
+}
+
+// head returns the head of the ith word, or 0 if it has none (or it is the root)
+func (m *patternMatcher) head(i int) int {
+	if h := m.as[i].HeadID(); h > 0 && h < len(m.as) && h != i {
+		return h
+	}
+	return 0
+}
+
+// related returns the words that the ith word has the relation with
+func (m *patternMatcher) related(rel patternRel, i int) []int {
+	var retVal []int
+	switch rel.op {
+	case headOf:
+		for j := 1; j < len(m.as); j++ {
+			if m.head(j) == i && (rel.label == nil || rel.label.matches(m.as[j])) {
+				retVal = append(retVal, j)
+			}
+		}
+	case dependentOf:
+		if h := m.head(i); h > 0 && (rel.label == nil || rel.label.matches(m.as[i])) {
+			retVal = append(retVal, h)
+		}
+	case ancestorOf:
+		for j := 1; j < len(m.as); j++ {
+			if m.dominates(i, j) {
+				retVal = append(retVal, j)
+			}
+		}
+	case descendantOf:
+		for h, steps := m.head(i), 0; h > 0 && h != i && steps < len(m.as); h, steps = m.head(h), steps+1 {
+			retVal = append(retVal, h)
+		}
+	case justBefore:
+		if i+1 < len(m.as) {
+			retVal = append(retVal, i+1)
+		}
+	case before:
+		for j := i + 1; j < len(m.as); j++ {
+			retVal = append(retVal, j)
+		}
+	}
+	return retVal
+}
+
+// dominates checks if the ith word is an ancestor of the jth word. Cycles don't loop forever.
+func (m *patternMatcher) dominates(i, j int) bool {
+	for h, steps := m.head(j), 0; h > 0 && steps < len(m.as); h, steps = m.head(h), steps+1 {
+		if h == i {
+			return h != j
+		}
+	}
+	return false
+}
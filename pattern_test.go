@@ -0,0 +1,92 @@
+package lingua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// passiveSentence is "Buku itu dibaca oleh Ani ."
+func passiveSentence() AnnotatedSentence {
+	word := func(value, lemma string, tag POSTag, rel DependencyType) *Annotation {
+		a := &Annotation{Lexeme: Lexeme{Value: value}, POSTag: tag, DependencyType: rel, Lemma: lemma}
+		a.Shape = a.Lexeme.Shape()
+		a.WordFlag = a.Lexeme.Flags()
+		return a
+	}
+	buku := word("Buku", "buku", NOUN, NSubj_Pass)
+	itu := word("itu", "itu", DET, Det)
+	dibaca := word("dibaca", "baca", VERB, Root)
+	oleh := word("oleh", "oleh", ADP, Case)
+	ani := word("Ani", "Ani", PROPN, Obl_Agent)
+	dot := word(".", ".", PUNCT, Punct)
+
+	buku.Head, itu.Head, dibaca.Head, oleh.Head, ani.Head, dot.Head = dibaca, buku, rootAnnotation, ani, dibaca, dibaca
+	as := AnnotatedSentence{rootAnnotation, buku, itu, dibaca, oleh, ani, dot}
+	as.SetID()
+	return as
+}
+
+func matchedValues(matches []Match, name string) []string {
+	var retVal []string
+	for _, m := range matches {
+		if name == "" {
+			retVal = append(retVal, m.Node.Value)
+		} else {
+			retVal = append(retVal, m.Bindings[name].Value)
+		}
+	}
+	return retVal
+}
+
+func TestPattern_Match(t *testing.T) {
+	assert := assert.New(t)
+	as := passiveSentence()
+
+	var patternTests = []struct {
+		pattern string
+		name    string
+		values  []string
+	}{
+		{"{}", "", []string{"Buku", "itu", "dibaca", "oleh", "Ani", "."}},
+		{"{tag:VERB;word:/^di/}=verb >obl:agent {}=agent", "agent", []string{"Ani"}},
+		{"{tag:VERB;word:/^di/}=verb >obl:agent ({}=agent > {lemma:oleh})", "verb", []string{"dibaca"}},
+		{"{tag:VERB} >/nsubj.*/ {}=subj", "subj", []string{"Buku"}},
+		{"{tag:VERB} >nsubj {}", "", nil},
+		{"{tag:NOUN;shape:Xxxx}", "", []string{"Buku"}},
+		{"{flag:IsTitle}", "", []string{"Buku", "Ani"}},
+		{"{flag:IsLower;tag:!PUNCT}", "", []string{"itu", "dibaca", "oleh"}},
+		{"{} < {word:dibaca}", "", []string{"Buku", "Ani", "."}},
+		{"{} <case {}=head", "head", []string{"Ani"}},
+		{"{word:dibaca} >> {}=d", "d", []string{"Buku", "itu", "oleh", "Ani", "."}},
+		{"{tag:DET} << {}=a", "a", []string{"Buku", "dibaca"}},
+		{"{tag:NOUN} . {}=next", "next", []string{"itu"}},
+		{"{tag:ADP} .. {tag:PUNCT}", "", []string{"oleh"}},
+		{"{tag:/NOUN|PROPN/} !> {tag:DET}", "", []string{"Ani"}},
+		{"{rel:root} > {}=x > {}=x", "x", []string{"Buku", "Ani", "."}},
+		{"{rel:nsubj:pass}", "", []string{"Buku"}},
+		{"({tag:VERB}=v > {tag:NOUN}) > {tag:PROPN}=a", "a", []string{"Ani"}},
+		{"({tag:VERB} > ({tag:PROPN} > {tag:ADP})) !> {tag:DET}", "", []string{"dibaca"}},
+		{"{tag:VERB} > ({tag:NOUN} > {tag:DET}) > {tag:PUNCT}=p", "p", []string{"."}},
+	}
+	for _, tt := range patternTests {
+		p, err := CompilePattern(tt.pattern)
+		if !assert.NoError(err, tt.pattern) {
+			continue
+		}
+		assert.Equal(tt.values, matchedValues(p.Match(as), tt.name), tt.pattern)
+	}
+
+	d := NewDependency(FromAnnotatedSentence(as))
+	assert.Len(MustCompilePattern("{} > {}").MatchDependency(d), 5)
+}
+
+func TestCompilePattern_Errors(t *testing.T) {
+	assert := assert.New(t)
+	for _, s := range []string{"", "{", "{tag}", "{foo:bar}", "{rel:foo}", "{flag:/x/}", "{flag:Nope}", "{word:/[/}", "{} >", "{} ? {}", "({}", "{}=", "{} {}", "{word:}", "({}) > ({}", "({} > {}))"} {
+		_, err := CompilePattern(s)
+		assert.Error(err, s)
+	}
+	assert.Panics(func() { MustCompilePattern("{") })
+	assert.Equal("{}", MustCompilePattern("{}").String())
+}
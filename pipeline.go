@@ -0,0 +1,150 @@
+package lingua
+
+import (
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// LexerFunc is a function that can be used as a Lexer
+type LexerFunc func(io.Reader) (LexemeSentence, error)
+
+func (f LexerFunc) Lex(r io.Reader) (LexemeSentence, error) { return f(r) }
+
+// DefaultLexer lexes with a *Tokenizer. Space lexemes are kept, so that the Splitter can find paragraph breaks.
+var DefaultLexer Lexer = LexerFunc(func(r io.Reader) (LexemeSentence, error) { return NewTokenizer(r).Lexemes() })
+
+// Pipeline turns raw text into AnnotatedSentences. It is made of stages, run in this order:
+//		Lexer    - breaks the text into Lexemes
+//		Splitter - splits the Lexemes into sentences
+//		Tagger   - sets the POSTags
+//		Fixer    - processes each *Annotation (stem, lemma, cluster). It runs after the Tagger because lemmas depend on the POSTag
+//		Parser   - parses the dependencies
+// The Lexer and Splitter are always available. Any other stage may be missing, in which case it's skipped.
+// A stage that returns an error that has a Component() method (i.e. the component is unavailable) is skipped too.
+type Pipeline struct {
+	lexer    Lexer
+	splitter Splitter
+	fixer    AnnotationFixer
+	tagger   Tagger
+	parser   Parser
+}
+
+type pipelineConsOpt func(*Pipeline)
+
+// WithLexer sets the Lexer of the pipeline
+func WithLexer(l Lexer) pipelineConsOpt {
+	return func(p *Pipeline) { p.lexer = l }
+}
+
+// WithSplitter sets the Splitter of the pipeline
+func WithSplitter(s Splitter) pipelineConsOpt {
+	return func(p *Pipeline) { p.splitter = s }
+}
+
+// WithFixer sets the AnnotationFixer of the pipeline
+func WithFixer(f AnnotationFixer) pipelineConsOpt {
+	return func(p *Pipeline) { p.fixer = f }
+}
+
+// WithTagger sets the Tagger of the pipeline
+func WithTagger(t Tagger) pipelineConsOpt {
+	return func(p *Pipeline) { p.tagger = t }
+}
+
+// WithParser sets the Parser of the pipeline
+func WithParser(ps Parser) pipelineConsOpt {
+	return func(p *Pipeline) { p.parser = ps }
+}
+
+// NewPipeline creates a new *Pipeline. By default it uses DefaultLexer and NewSentenceSplitter(). It takes optional construction options:
+//		WithLexer
+//		WithSplitter
+//		WithFixer
+//		WithTagger
+//		WithParser
+func NewPipeline(opts ...pipelineConsOpt) *Pipeline {
+	p := &Pipeline{
+		lexer:    DefaultLexer,
+		splitter: NewSentenceSplitter(),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Process reads the text and returns its sentences. The IDs of each sentence are set, and the heads are fixed if there is a Parser.
+func (p *Pipeline) Process(r io.Reader) ([]AnnotatedSentence, error) {
+	lexemes, err := p.lexer.Lex(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "Lexer failed")
+	}
+
+	var retVal []AnnotatedSentence
+	for _, ls := range p.splitter.Split(lexemes) {
+		if len(ls) == 0 {
+			continue
+		}
+		as, err := p.sentence(ls)
+		if err != nil {
+			return retVal, errors.Wrapf(err, "Sentence %d", len(retVal))
+		}
+		retVal = append(retVal, as)
+	}
+	return retVal, nil
+}
+
+// ProcessString is a convenience method to process a string.
+func (p *Pipeline) ProcessString(s string) ([]AnnotatedSentence, error) {
+	return p.Process(strings.NewReader(s))
+}
+
+// Sentencers reads the text and returns a Sentencer for each sentence. If the pipeline has a Parser, the Sentencers are the parsed *Dependency.
+func (p *Pipeline) Sentencers(r io.Reader) ([]Sentencer, error) {
+	sentences, err := p.Process(r)
+	retVal := make([]Sentencer, 0, len(sentences))
+	for _, as := range sentences {
+		retVal = append(retVal, as.Dependency())
+	}
+	return retVal, err
+}
+
+func (p *Pipeline) sentence(ls LexemeSentence) (AnnotatedSentence, error) {
+	// lemmas depend on the POSTags, so the fixer is only used after tagging
+	as, err := ls.AnnotatedSentence(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tagger != nil {
+		if err = p.tagger.Tag(as); err != nil && !isUnavailable(err) {
+			return nil, errors.Wrap(err, "Tagger failed")
+		}
+	}
+
+	if p.fixer != nil {
+		for _, a := range as[1:] {
+			if err = a.Process(p.fixer); err != nil {
+				return nil, errors.Wrapf(err, "Unable to process %q", a.Value)
+			}
+		}
+	}
+
+	if p.parser != nil {
+		var dep *Dependency
+		dep, err = p.parser.Parse(as)
+		switch {
+		case err != nil && !isUnavailable(err):
+			return nil, errors.Wrap(err, "Parser failed")
+		case err == nil && dep != nil:
+			as = dep.Sentence()
+			as.Fix()
+			return as, nil
+		}
+	}
+
+	as.SetID()
+	return as, nil
+}
@@ -0,0 +1,106 @@
+package lingua
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+// dictTagger tags words found in its dictionary
+type dictTagger map[string]POSTag
+
+func (t dictTagger) Tag(as AnnotatedSentence) error {
+	for _, a := range as[1:] {
+		if tag, ok := t[a.Lowered]; ok {
+			a.POSTag = tag
+		}
+	}
+	return nil
+}
+
+type unavailableTagger struct{}
+
+func (unavailableTagger) Tag(AnnotatedSentence) error {
+	return errors.Wrap(ComponentUnavailableError{"tagger"}, "no model")
+}
+
+// rightParser attaches every word to the word on its right, and the last word to the root
+type rightParser struct{}
+
+func (rightParser) Parse(as AnnotatedSentence) (*Dependency, error) {
+	for i := 1; i < len(as); i++ {
+		if i == len(as)-1 {
+			as[i].SetHead(rootAnnotation)
+			as[i].DependencyType = Root
+			continue
+		}
+		as[i].SetHead(as[i+1])
+		as[i].DependencyType = Dep
+	}
+	return as.Dependency(), nil
+}
+
+// memLemmatizer lemmatizes verbs by removing the meN- prefix
+type memLemmatizer struct{}
+
+func (memLemmatizer) Lemmatize(w string, t POSTag) ([]string, error) {
+	if t == VERB && len(w) > 3 && w[:3] == "mem" {
+		return []string{"p" + w[3:]}, nil
+	}
+	return []string{w}, nil
+}
+
+func TestPipeline(t *testing.T) {
+	assert := assert.New(t)
+	text := "Ani memukul bola. Bola itu bulat!"
+
+	// no optional stages
+	sentences, err := NewPipeline().ProcessString(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(sentences))
+	assert.Equal("-ROOT- Ani memukul bola .", sentences[0].ValueString())
+	assert.Equal([]int{0, 1, 2, 3, 4}, sentences[0].IDs())
+	assert.Equal(X, sentences[0][2].POSTag)
+
+	// all stages
+	p := NewPipeline(
+		WithTagger(dictTagger{"ani": PROPN, "memukul": VERB, "bola": NOUN, "itu": DET, "bulat": ADJ}),
+		WithFixer(NewFixer(WithLemmatizer(memLemmatizer{}))),
+		WithParser(rightParser{}),
+	)
+	sentences, err = p.ProcessString(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(sentences))
+	s := sentences[0]
+	assert.Equal([]POSTag{ROOT_TAG, PROPN, VERB, NOUN, PUNCT}, s.Tags())
+	assert.Equal("pukul", s[2].Lemma, "the fixer should run after the tagger")
+	assert.Equal([]int{-1, 2, 3, 4, 0}, s.Heads())
+	assert.True(s.IsValid())
+
+	// unavailable stages are skipped
+	sentences, err = NewPipeline(WithTagger(unavailableTagger{}), WithFixer(NewFixer(WithLemmatizer(memLemmatizer{})))).ProcessString(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(X, sentences[0][2].POSTag)
+	assert.Equal("memukul", sentences[0][2].Lemma)
+}
+
+func TestPipeline_Sentencers(t *testing.T) {
+	sentencers, err := NewPipeline(WithParser(rightParser{})).Sentencers(strings.NewReader("Satu. Dua."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sentencers) != 2 {
+		t.Fatalf("Expected 2 sentences. Got %d instead", len(sentencers))
+	}
+	if s := sentencers[1].Sentence(); s.ValueString() != "-ROOT- Dua ." || s[1].HeadID() != 2 {
+		t.Errorf("Unexpected sentence %v", s)
+	}
+}
@@ -0,0 +1,127 @@
+package lingua
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PunktModel holds what has been learned by TrainPunkt. It's a simplified version of the unsupervised sentence boundary detection
+// described by Kiss and Strunk (2006). Only the abbreviation types and frequent sentence starters are learned.
+//
+// Every field is exported for easy gobbing.
+type PunktModel struct {
+	// Abbreviations are the lower cased word types (without the final full stop) that are most likely abbreviations
+	Abbreviations map[string]struct{}
+
+	// SentenceStarters are the lower cased word types that frequently start a sentence. An abbreviation followed by a sentence starter ends a sentence.
+	SentenceStarters map[string]struct{}
+}
+
+const (
+	punktAbbrevThreshold = 0.3
+	punktMinStarterCount = 2
+)
+
+// TrainPunkt learns a *PunktModel from raw text. Each LexemeSentence is expected to be the output of a *Tokenizer, with the Space lexemes intact.
+func TrainPunkt(texts ...LexemeSentence) *PunktModel {
+	m := &PunktModel{
+		Abbreviations:    make(map[string]struct{}),
+		SentenceStarters: make(map[string]struct{}),
+	}
+
+	// first pass: abbreviation types
+	counts := make(map[string]int)
+	periods := make(map[string]int)
+	var tokenCount, periodCount int
+	for _, text := range texts {
+		for i, lex := range text {
+			if lex.LexemeType != Space {
+				tokenCount++
+			}
+			if lex.Value == "." {
+				periodCount++
+			}
+			if lex.LexemeType != Word {
+				continue
+			}
+
+			w := strings.ToLower(lex.Value)
+			counts[w]++
+			if i+1 < len(text) && text[i+1].Value == "." {
+				periods[w]++
+			}
+		}
+	}
+
+	for w, withPeriod := range periods {
+		if punktAbbrevScore(w, counts[w], withPeriod, periodCount, tokenCount) >= punktAbbrevThreshold {
+			m.Abbreviations[w] = empty
+		}
+	}
+
+	// second pass: words that start sentences after unambiguous boundaries
+	starts := make(map[string]int)
+	for _, text := range texts {
+		for i, lex := range text {
+			if !isTerminator(lex) {
+				continue
+			}
+			if lex.Value == "." && i > 0 && text[i-1].LexemeType == Word {
+				if _, ok := m.Abbreviations[strings.ToLower(text[i-1].Value)]; ok {
+					continue
+				}
+			}
+
+			next, ok := nextNonSpace(text, i)
+			if !ok || next.LexemeType != Word || !startsWith(next.Value, unicode.IsUpper) {
+				continue
+			}
+			starts[strings.ToLower(next.Value)]++
+		}
+	}
+
+	for w, c := range starts {
+		if c >= punktMinStarterCount && 2*c >= counts[w] {
+			m.SentenceStarters[w] = empty
+		}
+	}
+	return m
+}
+
+// punktAbbrevScore scores how likely a word type is an abbreviation.
+//
+// The log likelihood ratio tests if the word is strongly collocated with a full stop. It is then penalized by length (abbreviations are short),
+// rewarded by the number of internal periods (e.g., S.H) and penalized by the number of times the word appears without a full stop.
+func punktAbbrevScore(w string, count, withPeriod, periodCount, tokenCount int) float64 {
+	ll := dunningLogLikelihood(count, periodCount, withPeriod, tokenCount)
+
+	internal := strings.Count(w, ".")
+	length := utf8.RuneCountInString(w) - internal
+
+	fLength := math.Exp(-float64(length))
+	fPeriods := float64(internal + 1)
+	fPenalty := math.Pow(float64(length), -float64(count-withPeriod))
+
+	return ll * fLength * fPeriods * fPenalty
+}
+
+// dunningLogLikelihood is the modified log likelihood ratio used by Punkt, where the alternative hypothesis is that
+// a word type is followed by a full stop 99% of the time.
+func dunningLogLikelihood(countA, countB, countAB, n int) float64 {
+	if n == 0 {
+		return 0
+	}
+
+	p1 := float64(countB) / float64(n)
+	if p1 >= 1 {
+		p1 = 0.99
+	}
+	p2 := 0.99
+
+	nullHypo := float64(countAB)*math.Log(p1) + float64(countA-countAB)*math.Log(1-p1)
+	altHypo := float64(countAB)*math.Log(p2) + float64(countA-countAB)*math.Log(1-p2)
+
+	return -2 * (nullHypo - altHypo)
+}
@@ -0,0 +1,129 @@
+package lingua
+
+import (
+	"strings"
+	"unicode"
+)
+
+//go:generate stringer -type=Reduplication
+
+// Reduplication is the type of reduplication (kata ulang) of an Indonesian word.
+type Reduplication byte
+
+const (
+	NotReduplicated      Reduplication = iota
+	FullReduplication                  // anak-anak, anak2
+	PartialReduplication               // berlari-lari, anak-anaknya, kemerah-merahan, tembak-menembak
+	RhymingReduplication               // sayur-mayur, bolak-balik, warna-warni
+)
+
+// affixes that may be attached to one half of a partially reduplicated word
+var (
+	reduplicationPrefixes = []string{
+		"se", "ke", "be", "ber", "bel", "te", "ter", "di", "diper",
+		"me", "mem", "men", "meng", "menge", "meny", "memper",
+		"pe", "per", "pem", "pen", "peng", "penge", "peny",
+	}
+	reduplicationSuffixes = []string{"an", "i", "kan", "nya", "ku", "mu", "lah", "kah", "pun"}
+)
+
+// Reduplicated checks if a word is reduplicated, and returns its base form with the reduplication undone.
+// The affixes of a partial reduplication are kept, so that the base form can be stemmed or lemmatized as usual:
+//		anak-anak       -> anak       (FullReduplication)
+//		anak2           -> anak       (FullReduplication)
+//		berlari-lari    -> berlari    (PartialReduplication)
+//		anak-anaknya    -> anaknya    (PartialReduplication)
+//		kemerah-merahan -> kemerahan  (PartialReduplication)
+//		tembak-menembak -> tembak     (PartialReduplication)
+//		sayur-mayur     -> sayur      (RhymingReduplication)
+// If the word is not reduplicated, it is returned as is, along with NotReduplicated.
+func Reduplicated(word string) (string, Reduplication) {
+	// informal writing: anak2, anak²
+	if n := len(word); n > 2 && (word[n-1] == '2' || strings.HasSuffix(word, "²")) {
+		base := strings.TrimSuffix(strings.TrimSuffix(word, "2"), "²")
+		if len([]rune(base)) >= 2 && StringIs(base, unicode.IsLetter) {
+			return base, FullReduplication
+		}
+		return word, NotReduplicated
+	}
+
+	if strings.Count(word, "-") != 1 {
+		return word, NotReduplicated
+	}
+	parts := strings.Split(word, "-")
+	left, right := []rune(parts[0]), []rune(parts[1])
+	if len(left) < 2 || len(right) < 2 || !StringIs(parts[0], unicode.IsLetter) || !StringIs(parts[1], unicode.IsLetter) {
+		return word, NotReduplicated
+	}
+	l, r := []rune(strings.ToLower(parts[0])), []rune(strings.ToLower(parts[1]))
+	if len(l) != len(left) || len(r) != len(right) {
+		// lower casing changed the length; don't bother
+		return word, NotReduplicated
+	}
+
+	if string(l) == string(r) {
+		return parts[0], FullReduplication
+	}
+
+	// the end of the left half is repeated at the start of the right half: ber[lari]-[lari], [anak]-[anak]nya
+	for k := minInt(len(l), len(r)); k >= 3; k-- {
+		if string(l[len(l)-k:]) != string(r[:k]) {
+			continue
+		}
+		prefix, suffix := string(l[:len(l)-k]), string(r[k:])
+		if (prefix == "" || InStringSlice(prefix, reduplicationPrefixes)) && (suffix == "" || InStringSlice(suffix, reduplicationSuffixes)) {
+			return string(left) + string(right[k:]), PartialReduplication
+		}
+	}
+
+	// the right half is the left half with a prefix, and possibly a nasalized initial: tembak-menembak, bantu-membantu
+	for _, tail := range []string{string(l), string(l[1:])} {
+		if len(r) > len(l) && strings.HasSuffix(string(r), tail) {
+			prefix := strings.TrimSuffix(string(r), tail)
+			if InStringSlice(prefix, reduplicationPrefixes) {
+				return parts[0], PartialReduplication
+			}
+		}
+	}
+
+	if isRhyme(l, r) {
+		return parts[0], RhymingReduplication
+	}
+	return word, NotReduplicated
+}
+
+// isRhyme checks if two halves of a word differ only in their initial consonant (sayur-mayur) or their vowels (bolak-balik), or both (hiruk-pikuk).
+func isRhyme(l, r []rune) bool {
+	if len(l) != len(r) || len(l) < 3 {
+		return false
+	}
+	for i := range l {
+		lv, rv := isIndonesianVowel(l[i]), isIndonesianVowel(r[i])
+		switch {
+		case lv != rv:
+			return false
+		case lv:
+			// vowels may differ
+		case i > 0 && l[i] != r[i]:
+			return false
+		}
+	}
+	return true
+}
+
+func isIndonesianVowel(r rune) bool { return strings.ContainsRune("aeiou", r) }
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Reduplication returns the base form of the lexeme and its type of reduplication. See Reduplicated.
+func (l Lexeme) Reduplication() (string, Reduplication) {
+	if l.LexemeType != Word {
+		return l.Value, NotReduplicated
+	}
+	return Reduplicated(l.Value)
+}
@@ -0,0 +1,68 @@
+package lingua
+
+import "testing"
+
+var reduplicationTests = []struct {
+	word, base string
+	r          Reduplication
+}{
+	{"anak-anak", "anak", FullReduplication},
+	{"Kupu-kupu", "Kupu", FullReduplication},
+	{"anak2", "anak", FullReduplication},
+	{"anak²", "anak", FullReduplication},
+	{"berlari-lari", "berlari", PartialReduplication},
+	{"anak-anaknya", "anaknya", PartialReduplication},
+	{"kemerah-merahan", "kemerahan", PartialReduplication},
+	{"sebaik-baiknya", "sebaiknya", PartialReduplication},
+	{"tembak-menembak", "tembak", PartialReduplication},
+	{"bahu-membahu", "bahu", PartialReduplication},
+	{"sayur-mayur", "sayur", RhymingReduplication},
+	{"bolak-balik", "bolak", RhymingReduplication},
+	{"warna-warni", "warna", RhymingReduplication},
+	{"mondar-mandir", "mondar", RhymingReduplication},
+
+	{"anak", "anak", NotReduplicated},
+	{"ke-2", "ke-2", NotReduplicated},
+	{"Jawa-Bali", "Jawa-Bali", NotReduplicated},
+	{"besar-kecil", "besar-kecil", NotReduplicated},
+	{"kerja-jawab", "kerja-jawab", NotReduplicated},
+	{"a-a", "a-a", NotReduplicated},
+	{"x-ray-x", "x-ray-x", NotReduplicated},
+}
+
+func TestReduplicated(t *testing.T) {
+	for _, rt := range reduplicationTests {
+		base, r := Reduplicated(rt.word)
+		if base != rt.base || r != rt.r {
+			t.Errorf("Expected %q to be %v with base %q. Got %v with base %q instead", rt.word, rt.r, rt.base, r, base)
+		}
+	}
+}
+
+func TestReduplication_Tokenizer(t *testing.T) {
+	lexemes := Tokenize("Anak² bermain layang-layang, sayur-mayur dan 2-3 buah.")
+	expected := map[string]Reduplication{
+		"Anak²":         FullReduplication,
+		"bermain":       NotReduplicated,
+		"layang-layang": FullReduplication,
+		"sayur-mayur":   RhymingReduplication,
+		"2-3":           NotReduplicated,
+	}
+	for _, l := range lexemes {
+		r, ok := expected[l.Value]
+		if !ok {
+			continue
+		}
+		delete(expected, l.Value)
+
+		if _, got := l.Reduplication(); got != r {
+			t.Errorf("Expected %q to be %v. Got %v instead", l.Value, r, got)
+		}
+		if flagged := l.Flags()&(1<<IsReduplicated) != 0; flagged != (r != NotReduplicated) {
+			t.Errorf("Expected the IsReduplicated flag of %q to be %t", l.Value, r != NotReduplicated)
+		}
+	}
+	for w := range expected {
+		t.Errorf("Expected %q to be tokenized as a single lexeme", w)
+	}
+}
@@ -23,6 +23,50 @@ func (ls LexemeSentence) String() string {
 	return strings.Trim(buf.String(), " ")
 }
 
+// Detokenize rebuilds the text of the LexemeSentence. See Detokenize.
+func (ls LexemeSentence) Detokenize() string { return Detokenize(ls) }
+
+// Detokenize rebuilds the text the Lexemes were read from, by joining each Lexeme with its SpaceAfter.
+// Space lexemes are written out only if they aren't already the SpaceAfter of the Lexeme before them (e.g. whitespace at the start of a text),
+// so the result is the same whether or not the Space lexemes were kept.
+func Detokenize(ls LexemeSentence) string {
+	var buf bytes.Buffer
+	for i, lex := range ls {
+		if lex.LexemeType == Space {
+			if i == 0 || ls[i-1].LexemeType == Space || ls[i-1].SpaceAfter != lex.Value {
+				buf.WriteString(lex.Value)
+			}
+			continue
+		}
+		buf.WriteString(lex.Value)
+		buf.WriteString(lex.SpaceAfter)
+	}
+	return buf.String()
+}
+
+// AnnotatedSentence creates an AnnotatedSentence out of the LexemeSentence, with the root annotation as the first element.
+// Each *Annotation is processed with the fixer, which is optional.
+func (ls LexemeSentence) AnnotatedSentence(f AnnotationFixer) (AnnotatedSentence, error) {
+	retVal := make(AnnotatedSentence, 0, len(ls)+1)
+	retVal = append(retVal, rootAnnotation)
+
+	for _, lex := range ls {
+		a := NewAnnotation()
+		a.Lexeme = lex
+		if t, ok := POSTagShortcut(lex); ok {
+			a.POSTag = t
+		}
+
+		if err := a.Process(f); err != nil {
+			return nil, errors.Wrapf(err, "Unable to process %q", lex.Value)
+		}
+		retVal = append(retVal, a)
+	}
+
+	retVal.SetID()
+	return retVal, nil
+}
+
 /* Annotated Sentence */
 
 // AnnotatedSentence is a sentence, but each word has been annotated.
@@ -84,9 +128,19 @@ func (as AnnotatedSentence) IsValid() bool {
 		return false
 	}
 
-	// TODO
-	// check that there is only one root
-
+	// a parsed sentence has only one root
+	roots, parsed := 0, false
+	for _, a := range as {
+		if a.Head != nil {
+			parsed = true
+			if a.HeadID() == 0 {
+				roots++
+			}
+		}
+	}
+	if parsed && roots != 1 {
+		return false
+	}
 	return true
 }
 
@@ -282,6 +336,18 @@ func (as AnnotatedSentence) ValueString() string {
 	return buf.String()
 }
 
+// Detokenize rebuilds the text of the sentence from the SpaceAfter of each Annotation. The root annotation is skipped. See Detokenize.
+func (as AnnotatedSentence) Detokenize() string {
+	ls := make(LexemeSentence, 0, len(as))
+	for _, a := range as {
+		if a == rootAnnotation || a.Lexeme == rootLexeme {
+			continue
+		}
+		ls = append(ls, a.Lexeme)
+	}
+	return Detokenize(ls)
+}
+
 func (as AnnotatedSentence) LoweredString() string {
 	var buf bytes.Buffer
 	for i, a := range as {
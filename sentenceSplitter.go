@@ -0,0 +1,252 @@
+package lingua
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AbbreviationType describes how an abbreviation behaves when it is followed by a full stop.
+type AbbreviationType byte
+
+const (
+	// NonTerminal abbreviations never end a sentence. They are typically titles followed by a name: "dr. Budi", "Jl. Sudirman"
+	NonTerminal AbbreviationType = iota
+	// Numeric abbreviations do not end a sentence when followed by a number: "No. 5", "hlm. 12"
+	Numeric
+	// Terminal abbreviations end a sentence when followed by a capitalized word: "... apel, jeruk, dll. Semua ..."
+	Terminal
+)
+
+// Abbreviations is a list of abbreviations and their types. The keys are lower cased and do not include the final full stop.
+type Abbreviations map[string]AbbreviationType
+
+// IndonesianAbbreviations are the common Indonesian abbreviations
+var IndonesianAbbreviations = Abbreviations{
+	"a.n": NonTerminal, "bpk": NonTerminal, "d.a": NonTerminal, "dr": NonTerminal, "dra": NonTerminal, "drs": NonTerminal,
+	"h": NonTerminal, "hj": NonTerminal, "ir": NonTerminal, "jl": NonTerminal, "jln": NonTerminal, "kab": NonTerminal,
+	"kec": NonTerminal, "kel": NonTerminal, "kol": NonTerminal, "letjen": NonTerminal, "mayjen": NonTerminal,
+	"ny": NonTerminal, "prof": NonTerminal, "prov": NonTerminal, "pt": NonTerminal, "sdr": NonTerminal, "sdri": NonTerminal,
+	"u.p": NonTerminal, "yth": NonTerminal,
+
+	"hlm": Numeric, "no": Numeric, "tgl": Numeric, "rt": Numeric, "rw": Numeric,
+
+	"dkk": Terminal, "dll": Terminal, "dsb": Terminal, "dst": Terminal, "tbk": Terminal, "tsb": Terminal,
+}
+
+// EnglishAbbreviations are the common English abbreviations
+var EnglishAbbreviations = Abbreviations{
+	"cf": NonTerminal, "dr": NonTerminal, "e.g": NonTerminal, "i.e": NonTerminal, "mr": NonTerminal, "mrs": NonTerminal,
+	"ms": NonTerminal, "prof": NonTerminal, "rev": NonTerminal, "st": NonTerminal, "vs": NonTerminal,
+
+	"fig": Numeric, "no": Numeric, "nos": Numeric, "p": Numeric, "pp": Numeric, "vol": Numeric,
+
+	"co": Terminal, "corp": Terminal, "etc": Terminal, "inc": Terminal, "jr": Terminal, "ltd": Terminal, "sr": Terminal,
+}
+
+// DefaultAbbreviations returns a new list of abbreviations made from the Indonesian and English abbreviations.
+func DefaultAbbreviations() Abbreviations {
+	retVal := make(Abbreviations)
+	for _, list := range []Abbreviations{EnglishAbbreviations, IndonesianAbbreviations} {
+		for k, v := range list {
+			retVal[k] = v
+		}
+	}
+	return retVal
+}
+
+// SentenceSplitter splits a stream of Lexemes (typically from a *Tokenizer) into sentences.
+//
+// A sentence ends at a terminator (".", "!", "?", "...") unless the terminator is part of an abbreviation,
+// is followed by a lower cased word, or is within brackets or quotes. Closing brackets and quotes that follow a terminator
+// are kept with the sentence. Paragraph breaks (two or more newlines) always end a sentence.
+type SentenceSplitter struct {
+	abbrevs Abbreviations
+	punkt   *PunktModel
+}
+
+type splitterConsOpt func(*SentenceSplitter)
+
+// WithAbbreviations replaces the abbreviations the *SentenceSplitter knows about.
+func WithAbbreviations(abbrevs Abbreviations) splitterConsOpt {
+	fn := func(s *SentenceSplitter) {
+		s.abbrevs = abbrevs
+	}
+	return fn
+}
+
+// WithPunkt makes the *SentenceSplitter also use the abbreviations and sentence starters learned by TrainPunkt.
+func WithPunkt(m *PunktModel) splitterConsOpt {
+	fn := func(s *SentenceSplitter) {
+		s.punkt = m
+	}
+	return fn
+}
+
+// NewSentenceSplitter creates a new *SentenceSplitter. By default it uses DefaultAbbreviations(). It takes optional construction options:
+//		WithAbbreviations
+//		WithPunkt
+func NewSentenceSplitter(opts ...splitterConsOpt) *SentenceSplitter {
+	s := &SentenceSplitter{
+		abbrevs: DefaultAbbreviations(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Split splits the lexemes into sentences. Space lexemes are used to find paragraph breaks and are not returned.
+func (s *SentenceSplitter) Split(lexemes LexemeSentence) []LexemeSentence {
+	var retVal []LexemeSentence
+	cur := NewLexemeSentence()
+	var depth int
+	var inQuote bool
+
+	flush := func() {
+		if len(cur) > 0 {
+			retVal = append(retVal, cur)
+			cur = NewLexemeSentence()
+		}
+		depth = 0
+		inQuote = false
+	}
+
+	for i := 0; i < len(lexemes); i++ {
+		lex := lexemes[i]
+		switch lex.LexemeType {
+		case Space:
+			if strings.Count(lex.Value, "\n") > 1 {
+				flush()
+			}
+			continue
+		case EOF:
+			continue
+		}
+
+		cur = append(cur, lex)
+		depth, inQuote = track(lex.Value, depth, inQuote)
+		if !isTerminator(lex) {
+			continue
+		}
+
+		// closing brackets, closing quotes and more terminators are kept with the sentence
+		j := i + 1
+		d, q := depth, inQuote
+		for ; j < len(lexemes); j++ {
+			next := lexemes[j]
+			if !(isTerminator(next) || isCloser(next.Value) || (isQuote(next.Value) && q)) {
+				break
+			}
+			d, q = track(next.Value, d, q)
+		}
+
+		if d > 0 || q || !s.isBoundary(lexemes, i, j-1) {
+			continue
+		}
+		cur = append(cur, lexemes[i+1:j]...)
+		i = j - 1
+		flush()
+	}
+	flush()
+	return retVal
+}
+
+// isBoundary checks if the terminator at i ends a sentence. last is the index of the last closer or terminator following it.
+func (s *SentenceSplitter) isBoundary(lexemes LexemeSentence, i, last int) bool {
+	next, ok := nextNonSpace(lexemes, last)
+	if !ok {
+		return true
+	}
+
+	if lexemes[i].Value != "." {
+		// "!", "?" and ellipses
+		return !startsWith(next.Value, unicode.IsLower)
+	}
+
+	if startsWith(next.Value, unicode.IsLower) {
+		return false
+	}
+
+	// abbreviations have to be attached to the full stop
+	if i == 0 || lexemes[i-1].LexemeType != Word {
+		return true
+	}
+	prev := strings.ToLower(lexemes[i-1].Value)
+
+	if s.punkt != nil {
+		if _, ok := s.punkt.Abbreviations[prev]; ok {
+			_, starter := s.punkt.SentenceStarters[strings.ToLower(next.Value)]
+			return starter
+		}
+	}
+
+	if t, ok := s.abbrevs[prev]; ok {
+		switch t {
+		case NonTerminal:
+			return false
+		case Numeric:
+			return next.LexemeType != Number
+		case Terminal:
+			return startsWith(next.Value, unicode.IsUpper)
+		}
+	}
+
+	// initials and dotted abbreviations (J. K. Rowling, S.H.)
+	if utf8.RuneCountInString(prev) == 1 && startsWith(prev, unicode.IsLetter) {
+		return false
+	}
+	if strings.Contains(prev, ".") {
+		return false
+	}
+	return true
+}
+
+/* utility functions */
+
+func nextNonSpace(lexemes LexemeSentence, i int) (Lexeme, bool) {
+	for j := i + 1; j < len(lexemes); j++ {
+		if lexemes[j].LexemeType != Space && lexemes[j].LexemeType != EOF {
+			return lexemes[j], true
+		}
+	}
+	return nullLexeme, false
+}
+
+func startsWith(s string, f is) bool {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r != utf8.RuneError && f(r)
+}
+
+func isTerminator(l Lexeme) bool {
+	if l.LexemeType != Punctuation {
+		return false
+	}
+	return StringIs(l.Value, func(r rune) bool {
+		switch r {
+		case '.', '!', '?', '…':
+			return true
+		}
+		return false
+	})
+}
+
+func isOpener(s string) bool { return InStringSlice(s, []string{"(", "[", "{", "“", "‘", "«"}) }
+func isCloser(s string) bool { return InStringSlice(s, []string{")", "]", "}", "”", "’", "»"}) }
+func isQuote(s string) bool  { return s == `"` }
+
+// track keeps track of the bracket depth and whether an ASCII quote is open.
+func track(s string, depth int, inQuote bool) (int, bool) {
+	switch {
+	case isOpener(s):
+		depth++
+	case isCloser(s):
+		if depth > 0 {
+			depth--
+		}
+	case isQuote(s):
+		inQuote = !inQuote
+	}
+	return depth, inQuote
+}
@@ -0,0 +1,76 @@
+package lingua
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tokenizeAll(s string) LexemeSentence {
+	lexemes, err := NewTokenizer(strings.NewReader(s)).Lexemes()
+	if err != nil {
+		panic(err)
+	}
+	return lexemes
+}
+
+var splitTests = []struct {
+	input     string
+	sentences []string
+}{
+	{"Saya lapar. Ayo makan!", []string{"Saya lapar .", "Ayo makan !"}},
+	{"Saya bertemu dr. Budi di Jl. Sudirman No. 5. Dia sehat.", []string{"Saya bertemu dr . Budi di Jl . Sudirman No . 5 .", "Dia sehat ."}},
+	{"Ada apel, jeruk, dll. Semua segar.", []string{"Ada apel , jeruk , dll .", "Semua segar ."}},
+	{"Some fruits, e.g. Apples, are red. Others are not.", []string{"Some fruits , e.g . Apples , are red .", "Others are not ."}},
+	{`Dia berkata, "Saya lapar. Ayo makan." Lalu pergi.`, []string{`Dia berkata , " Saya lapar . Ayo makan . "`, "Lalu pergi ."}},
+	{"Buku itu (lihat hlm. 12. Bab 2.) sangat bagus. Baca!", []string{"Buku itu ( lihat hlm . 12 . Bab 2 . ) sangat bagus .", "Baca !"}},
+	{"Judul\n\nIsi paragraf", []string{"Judul", "Isi paragraf"}},
+	{"J. K. Rowling menulis... Bagus?! Ya.", []string{"J . K . Rowling menulis ...", "Bagus ? !", "Ya ."}},
+}
+
+func TestSentenceSplitter_Split(t *testing.T) {
+	assert := assert.New(t)
+	s := NewSentenceSplitter()
+	for _, st := range splitTests {
+		sentences := s.Split(tokenizeAll(st.input))
+		got := make([]string, len(sentences))
+		for i, sentence := range sentences {
+			got[i] = sentence.String()
+		}
+		assert.Equal(st.sentences, got, "Input: %q", st.input)
+	}
+}
+
+func TestTrainPunkt(t *testing.T) {
+	assert := assert.New(t)
+	training := `Kantor kami di Kel. Menteng. Kantor itu besar. Ia bekerja di Kel. Cikini. Ia senang. Rumahnya di Kel. Tebet.
+Ia pindah ke Kel. Gambir. Kami lapar. Kami makan. Kantor tutup. Ia pulang.`
+
+	m := TrainPunkt(tokenizeAll(training))
+	assert.Contains(m.Abbreviations, "kel")
+	assert.NotContains(m.Abbreviations, "lapar")
+	assert.Contains(m.SentenceStarters, "ia")
+
+	s := NewSentenceSplitter(WithAbbreviations(Abbreviations{}), WithPunkt(m))
+	sentences := s.Split(tokenizeAll("Kantor di Kel. Menteng dekat. Dia tinggal di Kel. Ia senang."))
+	if assert.Len(sentences, 3) {
+		assert.Equal("Kantor di Kel . Menteng dekat .", sentences[0].String())
+		assert.Equal("Dia tinggal di Kel .", sentences[1].String())
+	}
+}
+
+func TestLexemeSentence_AnnotatedSentence(t *testing.T) {
+	assert := assert.New(t)
+	sentences := NewSentenceSplitter().Split(tokenizeAll("Harganya 1.000 rupiah."))
+	as, err := sentences[0].AnnotatedSentence(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(5, len(as))
+	assert.Equal(RootAnnotation(), as[0])
+	assert.Equal([]int{0, 1, 2, 3, 4}, as.IDs())
+	assert.Equal(NUM, as[2].POSTag)
+	assert.Equal(PUNCT, as[4].POSTag)
+}
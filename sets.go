@@ -7,10 +7,10 @@ import (
 
 /* TAG SET */
 
-// TagSet is a set of all the POSTags
-type TagSet [MAXTAG]bool
+// POSTagSet is a set of POSTags.
+type POSTagSet [MAXTAG]bool
 
-func (ts TagSet) String() string {
+func (ts POSTagSet) String() string {
 	var buf bytes.Buffer
 	for t, v := range ts {
 		buf.WriteString(fmt.Sprintf("%v: %v\n", POSTag(t), v))
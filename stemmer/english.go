@@ -0,0 +1,352 @@
+package stemmer
+
+import (
+	"strings"
+)
+
+// English is the Snowball English (Porter2) stemmer, as described in http://snowball.tartarus.org/algorithms/english/stemmer.html
+type English struct{}
+
+// NewEnglish creates a new *English stemmer
+func NewEnglish() *English { return new(English) }
+
+// Stem stems a word. The word is lower cased before stemming. The English stemmer never returns an error.
+func (s *English) Stem(word string) (string, error) {
+	return porter2(strings.ToLower(word)), nil
+}
+
+var porter2Exceptions = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idl",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+
+	// invariant forms
+	"sky":    "sky",
+	"news":   "news",
+	"howe":   "howe",
+	"atlas":  "atlas",
+	"cosmos": "cosmos",
+	"bias":   "bias",
+	"andes":  "andes",
+}
+
+// words that are left alone after step 1a
+var porter2Exceptions1a = map[string]struct{}{
+	"inning":  empty,
+	"outing":  empty,
+	"canning": empty,
+	"herring": empty,
+	"earring": empty,
+	"proceed": empty,
+	"exceed":  empty,
+	"succeed": empty,
+}
+
+// porter2Word holds the word being stemmed and its regions. Y is used for a consonant y.
+type porter2Word struct {
+	w      []byte
+	r1, r2 int
+}
+
+func porter2(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	word = strings.Replace(word, "’", "'", -1)
+	word = strings.TrimPrefix(word, "'")
+
+	if stem, ok := porter2Exceptions[word]; ok {
+		return stem
+	}
+
+	w := &porter2Word{w: []byte(word)}
+	w.markYs()
+	w.regions()
+
+	w.step0()
+	w.step1a()
+	if _, ok := porter2Exceptions1a[string(w.w)]; ok {
+		return string(w.w)
+	}
+	w.step1b()
+	w.step1c()
+	w.step2()
+	w.step3()
+	w.step4()
+	w.step5()
+
+	return strings.ToLower(string(w.w))
+}
+
+func isPorterVowel(c byte) bool {
+	switch c {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// markYs marks the initial y, and a y after a vowel as a consonant Y
+func (w *porter2Word) markYs() {
+	for i, c := range w.w {
+		if c == 'y' && (i == 0 || isPorterVowel(w.w[i-1])) {
+			w.w[i] = 'Y'
+		}
+	}
+}
+
+// regions finds R1 and R2. R1 is the region after the first non-vowel following a vowel. R2 is the R1 of R1.
+func (w *porter2Word) regions() {
+	w.r1 = len(w.w)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(string(w.w), prefix) {
+			w.r1 = len(prefix)
+			break
+		}
+	}
+	if w.r1 == len(w.w) {
+		w.r1 = w.regionAfter(0)
+	}
+	w.r2 = w.regionAfter(w.r1)
+}
+
+func (w *porter2Word) regionAfter(start int) int {
+	for i := start + 1; i < len(w.w); i++ {
+		if !isPorterVowel(w.w[i]) && isPorterVowel(w.w[i-1]) {
+			return i + 1
+		}
+	}
+	return len(w.w)
+}
+
+func (w *porter2Word) hasSuffix(suffix string) bool { return strings.HasSuffix(string(w.w), suffix) }
+
+// longestSuffix returns the longest of the suffixes that the word ends with
+func (w *porter2Word) longestSuffix(suffixes ...string) string {
+	var retVal string
+	for _, suf := range suffixes {
+		if len(suf) > len(retVal) && w.hasSuffix(suf) {
+			retVal = suf
+		}
+	}
+	return retVal
+}
+
+func (w *porter2Word) inR1(suffix string) bool { return len(w.w)-len(suffix) >= w.r1 }
+func (w *porter2Word) inR2(suffix string) bool { return len(w.w)-len(suffix) >= w.r2 }
+
+func (w *porter2Word) replace(suffix, with string) {
+	w.w = append(w.w[:len(w.w)-len(suffix)], with...)
+}
+
+// containsVowel checks if there is a vowel in w[:end]
+func (w *porter2Word) containsVowel(end int) bool {
+	if end <= 0 {
+		return false
+	}
+	for _, c := range w.w[:end] {
+		if isPorterVowel(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// endsInShortSyllable checks if w[:end] ends in a short syllable: a vowel followed by a non-vowel other than w, x or Y and preceded by a non-vowel,
+// or a vowel at the beginning of the word followed by a non-vowel.
+func (w *porter2Word) endsInShortSyllable(end int) bool {
+	switch {
+	case end == 2:
+		return isPorterVowel(w.w[0]) && !isPorterVowel(w.w[1])
+	case end >= 3:
+		c := w.w[end-1]
+		return !isPorterVowel(w.w[end-3]) && isPorterVowel(w.w[end-2]) && !isPorterVowel(c) && c != 'w' && c != 'x' && c != 'Y'
+	}
+	return false
+}
+
+func (w *porter2Word) isShort() bool { return w.r1 >= len(w.w) && w.endsInShortSyllable(len(w.w)) }
+
+func (w *porter2Word) step0() {
+	if suf := w.longestSuffix("'", "'s", "'s'"); suf != "" {
+		w.replace(suf, "")
+	}
+}
+
+func (w *porter2Word) step1a() {
+	switch suf := w.longestSuffix("sses", "ied", "ies", "us", "ss", "s"); suf {
+	case "sses":
+		w.replace(suf, "ss")
+	case "ied", "ies":
+		if len(w.w) > 4 {
+			w.replace(suf, "i")
+		} else {
+			w.replace(suf, "ie")
+		}
+	case "s":
+		// delete if the preceding word part contains a vowel not immediately before the s
+		if w.containsVowel(len(w.w) - 2) {
+			w.replace(suf, "")
+		}
+	}
+}
+
+func (w *porter2Word) step1b() {
+	switch suf := w.longestSuffix("eed", "eedly", "ed", "edly", "ing", "ingly"); suf {
+	case "eed", "eedly":
+		if w.inR1(suf) {
+			w.replace(suf, "ee")
+		}
+	case "ed", "edly", "ing", "ingly":
+		if !w.containsVowel(len(w.w) - len(suf)) {
+			return
+		}
+		w.replace(suf, "")
+
+		switch {
+		case w.hasSuffix("at"), w.hasSuffix("bl"), w.hasSuffix("iz"):
+			w.w = append(w.w, 'e')
+		case w.endsInDouble():
+			w.w = w.w[:len(w.w)-1]
+		case w.isShort():
+			w.w = append(w.w, 'e')
+		}
+	}
+}
+
+func (w *porter2Word) endsInDouble() bool {
+	for _, d := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if w.hasSuffix(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// step1c replaces a final y or Y with i if it is preceded by a non-vowel which is not the first letter of the word
+func (w *porter2Word) step1c() {
+	n := len(w.w)
+	if n > 2 && (w.w[n-1] == 'y' || w.w[n-1] == 'Y') && !isPorterVowel(w.w[n-2]) {
+		w.w[n-1] = 'i'
+	}
+}
+
+var porter2Step2 = map[string]string{
+	"tional":  "tion",
+	"enci":    "ence",
+	"anci":    "ance",
+	"abli":    "able",
+	"entli":   "ent",
+	"izer":    "ize",
+	"ization": "ize",
+	"ational": "ate",
+	"ation":   "ate",
+	"ator":    "ate",
+	"alism":   "al",
+	"aliti":   "al",
+	"alli":    "al",
+	"fulness": "ful",
+	"ousli":   "ous",
+	"ousness": "ous",
+	"iveness": "ive",
+	"iviti":   "ive",
+	"biliti":  "ble",
+	"bli":     "ble",
+	"ogi":     "og",
+	"fulli":   "ful",
+	"lessli":  "less",
+	"li":      "",
+}
+
+func (w *porter2Word) step2() {
+	suf := w.longestSuffixIn(porter2Step2)
+	if suf == "" || !w.inR1(suf) {
+		return
+	}
+
+	n := len(w.w) - len(suf)
+	switch suf {
+	case "ogi":
+		if n > 0 && w.w[n-1] == 'l' {
+			w.replace(suf, "og")
+		}
+	case "li":
+		if n > 0 && strings.IndexByte("cdeghkmnrt", w.w[n-1]) >= 0 {
+			w.replace(suf, "")
+		}
+	default:
+		w.replace(suf, porter2Step2[suf])
+	}
+}
+
+var porter2Step3 = map[string]string{
+	"tional":  "tion",
+	"ational": "ate",
+	"alize":   "al",
+	"icate":   "ic",
+	"iciti":   "ic",
+	"ical":    "ic",
+	"ful":     "",
+	"ness":    "",
+	"ative":   "",
+}
+
+func (w *porter2Word) step3() {
+	suf := w.longestSuffixIn(porter2Step3)
+	if suf == "" || !w.inR1(suf) {
+		return
+	}
+	if suf == "ative" && !w.inR2(suf) {
+		return
+	}
+	w.replace(suf, porter2Step3[suf])
+}
+
+var porter2Step4 = []string{"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion"}
+
+func (w *porter2Word) step4() {
+	suf := w.longestSuffix(porter2Step4...)
+	if suf == "" || !w.inR2(suf) {
+		return
+	}
+
+	if suf == "ion" {
+		n := len(w.w) - len(suf)
+		if n == 0 || (w.w[n-1] != 's' && w.w[n-1] != 't') {
+			return
+		}
+	}
+	w.replace(suf, "")
+}
+
+func (w *porter2Word) step5() {
+	n := len(w.w)
+	switch {
+	case w.hasSuffix("e"):
+		if w.inR2("e") || (w.inR1("e") && !w.endsInShortSyllable(n-1)) {
+			w.replace("e", "")
+		}
+	case w.hasSuffix("l"):
+		if w.inR2("l") && n > 1 && w.w[n-2] == 'l' {
+			w.replace("l", "")
+		}
+	}
+}
+
+func (w *porter2Word) longestSuffixIn(m map[string]string) string {
+	var retVal string
+	for suf := range m {
+		if len(suf) > len(retVal) && w.hasSuffix(suf) {
+			retVal = suf
+		}
+	}
+	return retVal
+}
@@ -0,0 +1,57 @@
+package stemmer
+
+import (
+	"bufio"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+)
+
+var _ lingua.Stemmer = &English{}
+
+func readLines(t *testing.T, filename string) []string {
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gz.Close()
+
+	var retVal []string
+	s := bufio.NewScanner(gz)
+	for s.Scan() {
+		retVal = append(retVal, s.Text())
+	}
+	if err = s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return retVal
+}
+
+// TestEnglish_Stem checks the stemmer against the vocabulary (testdata/voc.txt.gz) and the expected output (testdata/output.txt.gz)
+// published by the Snowball project. See testdata/README.md for where the files come from.
+func TestEnglish_Stem(t *testing.T) {
+	voc := readLines(t, "testdata/voc.txt.gz")
+	output := readLines(t, "testdata/output.txt.gz")
+	if len(voc) != len(output) {
+		t.Fatalf("Expected the vocabulary and the output to have the same length. Got %d and %d", len(voc), len(output))
+	}
+
+	s := NewEnglish()
+	for i, word := range voc {
+		stem, err := s.Stem(word)
+		if err != nil {
+			t.Errorf("Stemming %q: %v", word, err)
+			continue
+		}
+		if stem != output[i] {
+			t.Errorf("Expected %q to be stemmed to %q. Got %q instead", word, output[i], stem)
+		}
+	}
+}
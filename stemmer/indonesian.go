@@ -0,0 +1,321 @@
+package stemmer
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+)
+
+// Indonesian is an Enhanced Confix Stripping stemmer (Arifin, Mahendra and Ciptaningtyas, 2009),
+// which is an improvement on the Nazief-Adriani stemmer.
+//
+// A word is stemmed by removing its inflectional suffixes (particles -lah, -kah, -tah, -pun, then possessives -ku, -mu, -nya),
+// then its derivational suffix (-i, -kan, -an), then up to three derivational prefixes (di-, ke-, se-, be(r)-, te(r)-, me(N)-, pe(N)-, per-).
+// After each step the result is looked up in the dictionary of root words. If no root word can be found, the suffixes are restored
+// one at a time and the prefixes removed again. If that fails too, the original word is returned.
+//
+// The morphophonemic changes of the meN- and peN- prefixes (e.g. menulis -> tulis, menyapu -> sapu, memukul -> pukul) are
+// handled by trying each of the possible forms of the root word.
+//
+// Reduplicated words (anak-anak, berlari-lari, sayur-mayur) are stemmed by their base form, as given by lingua.Reduplicated.
+type Indonesian struct {
+	dict Dictionary
+}
+
+// NewIndonesian creates a new *Indonesian stemmer with the given dictionary of root words
+func NewIndonesian(dict Dictionary) *Indonesian {
+	return &Indonesian{dict: dict}
+}
+
+// Stem stems a word. The word is lower cased before stemming. An error is returned only if the stemmer has no dictionary.
+func (s *Indonesian) Stem(word string) (string, error) {
+	if len(s.dict) == 0 {
+		return word, errors.New("Indonesian stemmer requires a dictionary of root words")
+	}
+
+	w := strings.ToLower(word)
+	if base, r := lingua.Reduplicated(w); r != lingua.NotReduplicated {
+		w = base
+	}
+	return s.stemSingular(w), nil
+}
+
+func (s *Indonesian) stemSingular(w string) string {
+	if utf8.RuneCountInString(w) <= 3 || s.dict.Contains(w) {
+		return w
+	}
+
+	if hasConfixPrecedence(w) {
+		if r, ok := s.prefixesThenSuffixes(w); ok {
+			return r
+		}
+	}
+
+	if r, ok := s.suffixesThenPrefixes(w); ok {
+		return r
+	}
+	return w
+}
+
+// hasConfixPrecedence checks if the word is likely a confix (be-lah, be-an, di-i, me-i, pe-i, te-i) where the prefix is to be removed first.
+func hasConfixPrecedence(w string) bool {
+	switch {
+	case strings.HasPrefix(w, "be"):
+		return strings.HasSuffix(w, "lah") || strings.HasSuffix(w, "an")
+	case strings.HasPrefix(w, "di"), strings.HasPrefix(w, "me"), strings.HasPrefix(w, "pe"), strings.HasPrefix(w, "te"):
+		return strings.HasSuffix(w, "i")
+	}
+	return false
+}
+
+func (s *Indonesian) prefixesThenSuffixes(w string) (string, bool) {
+	return s.removePrefixes(w, "", 0, "", s.lookupWithoutSuffixes)
+}
+
+func (s *Indonesian) suffixesThenPrefixes(w string) (string, bool) {
+	w1, _ := removeParticle(w)
+	if s.dict.Contains(w1) {
+		return w1, true
+	}
+
+	w2, _ := removePossessive(w1)
+	if s.dict.Contains(w2) {
+		return w2, true
+	}
+
+	w3, suffix := removeDerivational(w2)
+	if s.dict.Contains(w3) {
+		return w3, true
+	}
+
+	if r, ok := s.removePrefixes(w3, suffix, 0, "", s.lookup); ok {
+		return r, true
+	}
+
+	// restore the suffixes one by one, and try removing the prefixes again
+	if suffix == "kan" {
+		// the suffix may have been -an on a root word that ends with k
+		if r, ok := s.lookupOrRemovePrefixes(w3+"k", "an"); ok {
+			return r, true
+		}
+	}
+	for _, c := range []string{w2, w1, w} {
+		if r, ok := s.lookupOrRemovePrefixes(c, ""); ok {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+func (s *Indonesian) lookup(w string) (string, bool) { return w, s.dict.Contains(w) }
+
+// lookupWithoutSuffixes looks up the word, then the word without its suffixes, removing one suffix at a time.
+func (s *Indonesian) lookupWithoutSuffixes(w string) (string, bool) {
+	if s.dict.Contains(w) {
+		return w, true
+	}
+	for _, remove := range []func(string) (string, string){removeParticle, removePossessive, removeDerivational} {
+		w, _ = remove(w)
+		if s.dict.Contains(w) {
+			return w, true
+		}
+	}
+	return "", false
+}
+
+func (s *Indonesian) lookupOrRemovePrefixes(w, suffix string) (string, bool) {
+	if s.dict.Contains(w) {
+		return w, true
+	}
+	return s.removePrefixes(w, suffix, 0, "", s.lookup)
+}
+
+// removePrefixes removes up to 3 prefixes. Every possible form of the root word is tried until one matches.
+func (s *Indonesian) removePrefixes(w, suffix string, depth int, prev string, match func(string) (string, bool)) (string, bool) {
+	if depth >= 3 {
+		return "", false
+	}
+
+	for _, c := range prefixCandidates(w) {
+		if depth == 0 && isDisallowedConfix(c.prefix, suffix) {
+			continue
+		}
+		if c.prefix == prev {
+			continue
+		}
+
+		if r, ok := match(c.rest); ok {
+			return r, true
+		}
+		if r, ok := s.removePrefixes(c.rest, suffix, depth+1, c.prefix, match); ok {
+			return r, true
+		}
+	}
+	return "", false
+}
+
+/* Suffixes */
+
+func removeSuffix(w string, suffixes ...string) (string, string) {
+	for _, suf := range suffixes {
+		if strings.HasSuffix(w, suf) && utf8.RuneCountInString(w)-len(suf) >= 2 {
+			return strings.TrimSuffix(w, suf), suf
+		}
+	}
+	return w, ""
+}
+
+func removeParticle(w string) (string, string)     { return removeSuffix(w, "lah", "kah", "tah", "pun") }
+func removePossessive(w string) (string, string)   { return removeSuffix(w, "nya", "ku", "mu") }
+func removeDerivational(w string) (string, string) { return removeSuffix(w, "kan", "an", "i") }
+
+// isDisallowedConfix checks the prefix-suffix pairs that do not occur in Indonesian: be-i, di-an, ke-i, ke-kan, me-an, se-i, se-kan
+func isDisallowedConfix(prefix, suffix string) bool {
+	if suffix == "" || len(prefix) < 2 {
+		return false
+	}
+
+	switch prefix[:2] {
+	case "be":
+		return suffix == "i"
+	case "di":
+		return suffix == "an"
+	case "ke", "se":
+		return suffix == "i" || suffix == "kan"
+	case "me":
+		return suffix == "an"
+	}
+	return false
+}
+
+/* Prefixes */
+
+type prefixCandidate struct {
+	prefix string // the normalized prefix that was removed
+	rest   string
+}
+
+func isVowel(c byte) bool {
+	switch c {
+	case 'a', 'i', 'u', 'e', 'o':
+		return true
+	}
+	return false
+}
+
+// at returns the byte at i, or 0 if i is out of range
+func at(w string, i int) byte {
+	if i < 0 || i >= len(w) {
+		return 0
+	}
+	return w[i]
+}
+
+// prefixCandidates returns the possible (prefix, rest) pairs of the word, in order of preference.
+// The rules follow the prefix disambiguation rules of the Enhanced Confix Stripping stemmer.
+func prefixCandidates(w string) (retVal []prefixCandidate) {
+	add := func(prefix, rest string) {
+		if len(rest) >= 2 {
+			retVal = append(retVal, prefixCandidate{prefix, rest})
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(w, "di"), strings.HasPrefix(w, "ke"), strings.HasPrefix(w, "se"):
+		add(w[:2], w[2:])
+
+	case strings.HasPrefix(w, "be"):
+		switch {
+		case strings.HasPrefix(w, "belajar"):
+			add("bel", w[3:])
+		case at(w, 2) == 'r' && isVowel(at(w, 3)):
+			add("ber", w[3:])
+			add("be", w[2:])
+		case at(w, 2) == 'r':
+			add("ber", w[3:])
+		case !isVowel(at(w, 2)) && at(w, 2) != 'l' && at(w, 3) == 'e' && at(w, 4) == 'r':
+			add("be", w[2:])
+		}
+
+	case strings.HasPrefix(w, "te"):
+		switch {
+		case at(w, 2) == 'r' && isVowel(at(w, 3)):
+			add("ter", w[3:])
+			add("te", w[2:])
+		case at(w, 2) == 'r':
+			add("ter", w[3:])
+		case !isVowel(at(w, 2)) && at(w, 3) == 'e' && at(w, 4) == 'r':
+			add("te", w[2:])
+		}
+
+	case strings.HasPrefix(w, "me"):
+		meN("me", w, add)
+
+	case strings.HasPrefix(w, "pe"):
+		switch {
+		case strings.HasPrefix(w, "pelajar"):
+			add("pel", w[3:])
+		case at(w, 2) == 'r' && isVowel(at(w, 3)):
+			add("per", w[3:])
+			add("pe", w[2:])
+		case at(w, 2) == 'r':
+			add("per", w[3:])
+		case at(w, 2) == 'l', at(w, 2) == 'w', at(w, 2) == 'y':
+			add("pe", w[2:])
+		case at(w, 2) == 'm', at(w, 2) == 'n':
+			meN("pe", w, add)
+		default:
+			if !isVowel(at(w, 2)) {
+				add("pe", w[2:])
+			}
+		}
+	}
+	return
+}
+
+// meN handles the nasal prefixes meN- and peN-, where N assimilates to the first consonant of the root word (which is sometimes dropped).
+// base is either "me" or "pe".
+func meN(base, w string, add func(prefix, rest string)) {
+	c2, c3, c4 := at(w, 2), at(w, 3), at(w, 4)
+	switch {
+	case strings.HasPrefix(w[2:], "ng"):
+		switch {
+		case isVowel(c4):
+			add(base+"ng", w[4:])
+			add(base+"ng", "k"+w[4:])
+			if c4 == 'e' {
+				add(base+"nge", w[5:]) // mengebom -> bom
+			}
+			add(base, w[2:])
+		case c4 == 'g' || c4 == 'h' || c4 == 'k' || c4 == 'q':
+			add(base+"ng", w[4:])
+		}
+
+	case strings.HasPrefix(w[2:], "ny") && isVowel(c4):
+		add(base+"ny", "s"+w[4:])
+		add(base, w[2:])
+
+	case c2 == 'm':
+		switch {
+		case c3 == 'b' || c3 == 'f' || c3 == 'v' || c3 == 'p':
+			add(base+"m", w[3:])
+		case c3 == 'r' || isVowel(c3):
+			add(base, w[2:])
+			add(base+"m", "p"+w[3:])
+		}
+
+	case c2 == 'n':
+		switch {
+		case c3 == 'c' || c3 == 'd' || c3 == 'j' || c3 == 's' || c3 == 'z' || c3 == 't':
+			add(base+"n", w[3:])
+		case isVowel(c3):
+			add(base, w[2:])
+			add(base+"n", "t"+w[3:])
+		}
+
+	case (c2 == 'l' || c2 == 'r' || c2 == 'w' || c2 == 'y') && isVowel(c3):
+		add(base, w[2:])
+	}
+}
@@ -0,0 +1,106 @@
+package stemmer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+)
+
+var _ lingua.Stemmer = &Indonesian{}
+
+const rootWords = `# a small dictionary of root words for testing
+ajar
+ambil
+anak
+baca
+baik
+baju
+beri
+bersih
+bom
+buku
+duduk
+jual
+kerja
+kirim
+lari
+lihat
+main
+sayur
+makan
+minum
+nyanyi
+pukul
+rumah
+sapu
+tani
+tanya
+tulis
+tunjuk
+`
+
+var indonesianStemTests = []struct {
+	word, stem string
+}{
+	{"makan", "makan"},
+	{"makanan", "makan"},
+	{"pelajaran", "ajar"},
+	{"belajar", "ajar"},
+	{"mempermainkan", "main"},
+	{"menyapu", "sapu"},
+	{"menulis", "tulis"},
+	{"memukul", "pukul"},
+	{"mengirim", "kirim"},
+	{"mengebom", "bom"},
+	{"meminum", "minum"},
+	{"bekerja", "kerja"},
+	{"pekerja", "kerja"},
+	{"berlari", "lari"},
+	{"petani", "tani"},
+	{"pembacaan", "baca"},
+	{"diberikan", "beri"},
+	{"kebersihan", "bersih"},
+	{"memperbaiki", "baik"},
+	{"bajunya", "baju"},
+	{"bukumu", "buku"},
+	{"rumahkulah", "rumah"},
+	{"duduklah", "duduk"},
+	{"terlihat", "lihat"},
+	{"penjualan", "jual"},
+	{"menyanyikan", "nyanyi"},
+	{"pengambilan", "ambil"},
+	{"ditunjukkan", "tunjuk"},
+	{"menanyakan", "tanya"},
+	{"Makanan", "makan"},
+	{"xyzabc", "xyzabc"},
+	{"anak-anak", "anak"},
+	{"anak2", "anak"},
+	{"berlari-lari", "lari"},
+	{"buku-bukunya", "buku"},
+	{"tulis-menulis", "tulis"},
+	{"sayur-mayur", "sayur"},
+}
+
+func TestIndonesian_Stem(t *testing.T) {
+	dict, err := ReadDictionary(strings.NewReader(rootWords))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewIndonesian(dict)
+	for _, st := range indonesianStemTests {
+		stem, err := s.Stem(st.word)
+		if err != nil {
+			t.Errorf("Stemming %q: %v", st.word, err)
+			continue
+		}
+		if stem != st.stem {
+			t.Errorf("Expected %q to be stemmed to %q. Got %q instead", st.word, st.stem, stem)
+		}
+	}
+
+	if _, err := NewIndonesian(nil).Stem("makanan"); err == nil {
+		t.Error("Expected an error when there is no dictionary")
+	}
+}
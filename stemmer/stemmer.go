@@ -0,0 +1,37 @@
+// package stemmer provides stemmers that implement lingua.Stemmer
+package stemmer
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+var empty struct{}
+
+// Dictionary is a set of root words.
+type Dictionary map[string]struct{}
+
+// ReadDictionary reads a list of root words, one word per line. Empty lines and lines starting with # are skipped.
+// The words are lower cased.
+func ReadDictionary(r io.Reader) (Dictionary, error) {
+	d := make(Dictionary)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		w := strings.TrimSpace(scanner.Text())
+		if len(w) == 0 || strings.HasPrefix(w, "#") {
+			continue
+		}
+		d[strings.ToLower(w)] = empty
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Contains returns true if the word is in the dictionary.
+func (d Dictionary) Contains(word string) bool {
+	_, ok := d[word]
+	return ok
+}
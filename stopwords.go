@@ -28,3 +28,18 @@ func UnescapeSpecials(word string) string {
 	}
 	return word
 }
+
+// EscapeSpecials is the reverse of UnescapeSpecials.
+func EscapeSpecials(word string) string {
+	switch word {
+	case "(":
+		return "-LRB-"
+	case ")":
+		return "-RRB-"
+	case "\"":
+		return "``"
+	case "":
+		return "-NULL-"
+	}
+	return word
+}
@@ -0,0 +1,186 @@
+package lingua
+
+import "github.com/pkg/errors"
+
+// TagMap maps the tags of a TagSet to the tags of another TagSet, e.g. the Penn Treebank tags to the Universal Dependencies tags.
+//
+// A mapping loses information when several tags are mapped to the same tag (e.g. NN and NNS are both NOUN). See Lossy and Losses.
+type TagMap struct {
+	From, To *TagSet
+
+	table []Tag
+	count []int // the number of tags of From that are mapped to each tag of To
+}
+
+// NewTagMap creates a TagMap. pairs are the names of the tags of from, and the names of the tags of to they are mapped to.
+// The tags of from that aren't in pairs are mapped to the first tag of to with the same POSTag (see FromPOSTag).
+func NewTagMap(from, to *TagSet, pairs map[string]string) (*TagMap, error) {
+	m := &TagMap{
+		From:  from,
+		To:    to,
+		table: make([]Tag, len(from.names)),
+		count: make([]int, len(to.names)),
+	}
+	for i := range from.names {
+		m.table[i], _ = to.FromPOSTag(from.coarse[i])
+	}
+	for f, t := range pairs {
+		ft, ok := from.Tag(f)
+		if !ok {
+			return nil, errors.Errorf("TagMap %v to %v: %q is not a tag of %v", from, to, f, from)
+		}
+		tt, ok := to.Tag(t)
+		if !ok {
+			return nil, errors.Errorf("TagMap %v to %v: %q is not a tag of %v", from, to, t, to)
+		}
+		m.table[ft.Index] = tt
+	}
+	for _, t := range m.table {
+		m.count[t.Index]++
+	}
+	return m, nil
+}
+
+func mustTagMap(m *TagMap, err error) *TagMap {
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Map maps a tag of From to a tag of To. Tags of any other TagSet are first converted to From by their POSTag.
+func (m *TagMap) Map(t Tag) Tag { return m.table[m.from(t).Index] }
+
+// MapPOSTag maps a POSTag, by way of the first tags of From and To with the POSTag.
+func (m *TagMap) MapPOSTag(p POSTag) POSTag {
+	t, _ := m.From.FromPOSTag(p)
+	return m.Map(t).POSTag()
+}
+
+// Lossy checks if the tag is mapped to a tag that other tags are also mapped to, so that the tag can't be recovered from the mapped tag.
+func (m *TagMap) Lossy(t Tag) bool { return m.count[m.Map(t).Index] > 1 }
+
+// Losses returns the tags of To that several tags of From are mapped to, and the tags that are mapped to them.
+func (m *TagMap) Losses() map[Tag][]Tag {
+	retVal := make(map[Tag][]Tag)
+	for i, t := range m.table {
+		if m.count[t.Index] > 1 {
+			retVal[t] = append(retVal[t], Tag{m.From.ID, uint16(i)})
+		}
+	}
+	return retVal
+}
+
+// Convert maps the tags. It returns the mapped tags, and the positions of the tags that lost information.
+func (m *TagMap) Convert(tags []Tag) (retVal []Tag, lossy []int) {
+	retVal = make([]Tag, len(tags))
+	for i, t := range tags {
+		retVal[i] = m.Map(t)
+		if m.Lossy(t) {
+			lossy = append(lossy, i)
+		}
+	}
+	return retVal, lossy
+}
+
+// ConvertPOSTags maps the POSTags. See MapPOSTag.
+func (m *TagMap) ConvertPOSTags(ps []POSTag) []POSTag {
+	retVal := make([]POSTag, len(ps))
+	for i, p := range ps {
+		retVal[i] = m.MapPOSTag(p)
+	}
+	return retVal
+}
+
+func (m *TagMap) from(t Tag) Tag {
+	if t.Set == m.From.ID && int(t.Index) < len(m.table) {
+		return t
+	}
+	t, _ = m.From.FromPOSTag(t.POSTag())
+	return t
+}
+
+// The built in TagMaps
+var (
+	// PennToUniversal maps the Penn Treebank tags to the Universal Dependencies tags, as the English treebanks of UD were converted.
+	PennToUniversal = mustTagMap(NewTagMap(PennTags, UniversalTags, nil))
+
+	// UniversalToPenn maps the Universal Dependencies tags to the most common Penn Treebank tag of each.
+	// The Penn Treebank distinctions that UD makes in the FEATS (e.g. NN and NNS) can't be recovered.
+	UniversalToPenn = mustTagMap(NewTagMap(UniversalTags, PennTags, map[string]string{
+		"X":     "FW",
+		"PART":  "TO",
+		"PRON":  "PRP",
+		"PUNCT": ".",
+		"SCONJ": "IN",
+	}))
+
+	// PennToPetrov is the en-ptb mapping of Petrov, Das and McDonald (2012).
+	PennToPetrov = mustTagMap(NewTagMap(PennTags, PetrovTags, map[string]string{
+		"-NULL-": "X",
+		"CC":     "CONJ",
+		"CD":     "NUM",
+		"DT":     "DET",
+		"EX":     "DET",
+		"FW":     "X",
+		"IN":     "ADP",
+		"JJ":     "ADJ",
+		"JJR":    "ADJ",
+		"JJS":    "ADJ",
+		"LS":     "X",
+		"MD":     "VERB",
+		"NN":     "NOUN",
+		"NNS":    "NOUN",
+		"NNP":    "NOUN",
+		"NNPS":   "NOUN",
+		"PDT":    "DET",
+		"POS":    "PRT",
+		"PRP":    "PRON",
+		"PRP$":   "PRON",
+		"RB":     "ADV",
+		"RBR":    "ADV",
+		"RBS":    "ADV",
+		"RP":     "PRT",
+		"SYM":    "X",
+		"TO":     "PRT",
+		"UH":     "X",
+		"VB":     "VERB",
+		"VBD":    "VERB",
+		"VBG":    "VERB",
+		"VBN":    "VERB",
+		"VBP":    "VERB",
+		"VBZ":    "VERB",
+		"WDT":    "DET",
+		"WP":     "PRON",
+		"WP$":    "PRON",
+		"WRB":    "ADV",
+		",":      ".",
+		"``":     ".",
+		"''":     ".",
+		".":      ".",
+		":":      ".",
+		"$":      ".",
+		"#":      ".",
+		"-LRB-":  ".",
+		"-RRB-":  ".",
+
+		// not in the original mapping
+		"ADD":  "X",
+		"NFP":  ".",
+		"HYPH": ".",
+		"GW":   "X",
+		"AFX":  "ADJ",
+		"XX":   "X",
+	}))
+
+	// UniversalToPetrov maps the Universal Dependencies tags to the tags of Petrov, Das and McDonald (2012).
+	UniversalToPetrov = mustTagMap(NewTagMap(UniversalTags, PetrovTags, map[string]string{
+		"AUX":   "VERB",
+		"INTJ":  "X",
+		"PART":  "PRT",
+		"PROPN": "NOUN",
+		"PUNCT": ".",
+		"SCONJ": "ADP",
+		"SYM":   "X",
+	}))
+)
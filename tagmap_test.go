@@ -0,0 +1,89 @@
+package lingua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPennToUniversal(t *testing.T) {
+	assert := assert.New(t)
+
+	for penn, ud := range map[string]POSTag{"NN": NOUN, "NNS": NOUN, "NNP": PROPN, "MD": AUX, "EX": PRON, "TO": PART, "-LRB-": PUNCT, "$": SYM} {
+		tag, _ := PennTags.Tag(penn)
+		assert.Equal(ud, PennToUniversal.Map(tag).POSTag(), penn)
+		assert.Equal(UniversalTags, PennToUniversal.Map(tag).TagSet(), penn)
+	}
+
+	nn, _ := PennTags.Tag("NN")
+	nns, _ := PennTags.Tag("NNS")
+	uh, _ := PennTags.Tag("UH")
+	assert.True(PennToUniversal.Lossy(nn))
+	assert.False(PennToUniversal.Lossy(uh))
+
+	losses := PennToUniversal.Losses()
+	noun, _ := UniversalTags.Tag("NOUN")
+	assert.Equal([]Tag{nn, nns}, losses[noun])
+	intj, _ := UniversalTags.Tag("INTJ")
+	assert.NotContains(losses, intj)
+}
+
+func TestUniversalToPenn(t *testing.T) {
+	assert := assert.New(t)
+
+	tags := []Tag{{UniversalTags.ID, uint16(PRON)}, {UniversalTags.ID, uint16(VERB)}, {UniversalTags.ID, uint16(SCONJ)}, {UniversalTags.ID, uint16(PUNCT)}}
+	converted, lossy := UniversalToPenn.Convert(tags)
+	var names []string
+	for _, t := range converted {
+		names = append(names, t.String())
+	}
+	assert.Equal([]string{"PRP", "VB", "IN", "."}, names)
+	assert.Equal([]int{2}, lossy, "ADP and SCONJ are both IN")
+
+	// every universal tag has a Penn tag
+	for _, t := range UniversalTags.Tags() {
+		assert.Equal(PennTags, UniversalToPenn.Map(t).TagSet())
+		if t.POSTag() != SCONJ {
+			assert.Equal(t.POSTag(), UniversalToPenn.Map(t).POSTag(), t.String())
+		}
+	}
+}
+
+func TestPetrovTags(t *testing.T) {
+	assert := assert.New(t)
+
+	for penn, petrov := range map[string]string{"NNP": "NOUN", "MD": "VERB", "EX": "DET", "TO": "PRT", "-LRB-": ".", "$": ".", "UH": "X", "SYM": "X"} {
+		tag, _ := PennTags.Tag(penn)
+		assert.Equal(petrov, PennToPetrov.Map(tag).String(), penn)
+	}
+	ps := []POSTag{PROPN, AUX, SCONJ, PART, PUNCT, INTJ, ROOT_TAG}
+	assert.Equal([]POSTag{NOUN, VERB, ADP, PART, PUNCT, X, ROOT_TAG}, UniversalToPetrov.ConvertPOSTags(ps))
+
+	// tags of other TagSets are mapped by their POSTag
+	nns, _ := PennTags.Tag("NNS")
+	assert.Equal("NOUN", UniversalToPetrov.Map(nns).String())
+}
+
+func TestNewTagMap(t *testing.T) {
+	_, err := NewTagMap(PennTags, UniversalTags, map[string]string{"NN": "NOUNS"})
+	assert.Error(t, err)
+	_, err = NewTagMap(PennTags, UniversalTags, map[string]string{"NNN": "NOUN"})
+	assert.Error(t, err)
+}
+
+func TestCCONJ(t *testing.T) {
+	assert := assert.New(t)
+
+	cconj, ok := UniversalTags.Tag("CCONJ")
+	assert.True(ok)
+	assert.Equal(CONJ, cconj.POSTag())
+	assert.Equal("CCONJ", cconj.String())
+	conj, ok := UniversalTags.Tag("CONJ")
+	assert.True(ok, "the UD v1 name is still read")
+	assert.Equal(cconj, conj)
+
+	cc, _ := PennTags.Tag("CC")
+	assert.Equal("CCONJ", PennToUniversal.Map(cc).String())
+	assert.Equal("CC", UniversalToPenn.Map(cconj).String())
+	assert.False(UniversalToPenn.Lossy(cconj))
+}
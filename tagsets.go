@@ -0,0 +1,337 @@
+package lingua
+
+import "strings"
+
+// The built in TagSets and RelationSets. UniversalTags and UniversalRelations are registered first, so their IDs are 0.
+var (
+	// UniversalTags are the Universal Dependencies POS tags. The tags are the POSTags themselves.
+	UniversalTags = mustTagSet(universalTags())
+
+	// PennTags are the Penn Treebank POS tags, as used by the Stanford tools and the English Web Treebank.
+	PennTags = mustTagSet(pennTags())
+
+	// PetrovTags are the 12 tags of the universal tagset of Petrov, Das and McDonald (2012), which predates Universal Dependencies.
+	// https://github.com/slavpetrov/universal-pos-tags
+	PetrovTags = mustTagSet(petrovTags())
+
+	// UniversalRelations are the Universal Dependencies relations, of both UD v1 and UD v2. The relations are the DependencyTypes themselves.
+	UniversalRelations = mustRelationSet(universalRelations())
+
+	// StanfordRelations are the Stanford Dependencies, mapped to the closest Universal Dependencies relation.
+	StanfordRelations = mustRelationSet(stanfordRelations())
+)
+
+func mustTagSet(ts *TagSet, err error) *TagSet {
+	if err != nil {
+		panic(err)
+	}
+	return ts
+}
+
+func mustRelationSet(rs *RelationSet, err error) *RelationSet {
+	if err != nil {
+		panic(err)
+	}
+	return rs
+}
+
+func universalTags() (*TagSet, error) {
+	names := make([]string, MAXTAG)
+	coarse := make([]POSTag, MAXTAG)
+	for t := X; t < MAXTAG; t++ {
+		names[t] = t.String()
+		coarse[t] = t
+	}
+	names[UNKNOWN_TAG] = "-UNKNOWN-"
+	names[ROOT_TAG] = "-ROOT-"
+	names[CONJ] = "CCONJ" // CONJ in UD v1
+
+	ts, err := RegisterTagSet("universal", names, coarse)
+	if err != nil {
+		return nil, err
+	}
+	ts.Alias("UNKNOWN_TAG", Tag{ts.ID, uint16(UNKNOWN_TAG)})
+	ts.Alias("ROOT_TAG", Tag{ts.ID, uint16(ROOT_TAG)})
+	ts.Alias("-NULL-", Tag{ts.ID, uint16(X)})
+	ts.Alias("CONJ", Tag{ts.ID, uint16(CONJ)})
+	return ts, nil
+}
+
+func pennTags() (*TagSet, error) {
+	tags := []struct {
+		name string
+		tag  POSTag
+	}{
+		{"-NULL-", X},
+		{"-ROOT-", ROOT_TAG},
+		{"-UNKNOWN-", UNKNOWN_TAG},
+
+		{"CC", CONJ},
+		{"CD", NUM},
+		{"DT", DET},
+		{"EX", PRON},
+		{"FW", X},
+		{"IN", ADP},
+		{"JJ", ADJ},
+		{"JJR", ADJ},
+		{"JJS", ADJ},
+		{"LS", X},
+		{"MD", AUX},
+		{"NN", NOUN},
+		{"NNS", NOUN},
+		{"NNP", PROPN},
+		{"NNPS", PROPN},
+		{"PDT", DET},
+		{"POS", PART},
+		{"PRP", PRON},
+		{"PRP$", PRON},
+		{"RB", ADV},
+		{"RBR", ADV},
+		{"RBS", ADV},
+		{"RP", ADP},
+		{"SYM", SYM},
+		{"TO", PART},
+		{"UH", INTJ},
+		{"VB", VERB},
+		{"VBD", VERB},
+		{"VBG", VERB},
+		{"VBN", VERB},
+		{"VBP", VERB},
+		{"VBZ", VERB},
+		{"WDT", DET},
+		{"WP", PRON},
+		{"WP$", PRON},
+		{"WRB", ADV},
+
+		// punctuation
+		{",", PUNCT},
+		{"``", PUNCT},
+		{"''", PUNCT},
+		{".", PUNCT},
+		{":", PUNCT},
+		{"$", SYM},
+		{"#", SYM},
+		{"-LRB-", PUNCT},
+		{"-RRB-", PUNCT},
+
+		// found in the English Web Treebank
+		{"ADD", X},
+		{"NFP", PUNCT},
+		{"HYPH", PUNCT},
+		{"GW", X},
+		{"AFX", ADJ},
+		{"XX", X},
+	}
+
+	names := make([]string, len(tags))
+	coarse := make([]POSTag, len(tags))
+	for i, t := range tags {
+		names[i], coarse[i] = t.name, t.tag
+	}
+	ts, err := RegisterTagSet("penn", names, coarse)
+	if err != nil {
+		return nil, err
+	}
+	// the names the tags used to have in lingua
+	for alias, name := range map[string]string{"X": "-NULL-", "PPRP": "PRP$", "PWP": "WP$"} {
+		t, _ := ts.Tag(name)
+		ts.Alias(alias, t)
+	}
+	return ts, nil
+}
+
+func petrovTags() (*TagSet, error) {
+	names := []string{"X", "-ROOT-", "-UNKNOWN-", "NOUN", "VERB", "ADJ", "ADV", "PRON", "DET", "ADP", "NUM", "CONJ", "PRT", "."}
+	coarse := []POSTag{X, ROOT_TAG, UNKNOWN_TAG, NOUN, VERB, ADJ, ADV, PRON, DET, ADP, NUM, CONJ, PART, PUNCT}
+	return RegisterTagSet("petrov", names, coarse)
+}
+
+func universalRelations() (*RelationSet, error) {
+	// http://universaldependencies.github.io/docs/en/dep/all.html
+	udNames := map[DependencyType]string{
+		NoDepType:     "-NULL-",
+		Dep:           "dep",
+		Root:          "root",
+		NSubj:         "nsubj",
+		NSubjPass:     "nsubjpass",
+		DObj:          "dobj",
+		IObj:          "iobj",
+		CSubj:         "csubj",
+		CSubjPass:     "csubjpass",
+		CComp:         "ccomp",
+		XComp:         "xcomp",
+		NumMod:        "nummod",
+		Appos:         "appos",
+		NMod:          "nmod",
+		ACl:           "acl",
+		ACl_RelCl:     "acl:relcl",
+		Det:           "det",
+		Det_PreDet:    "det:predet",
+		AMod:          "amod",
+		Neg:           "neg",
+		Case:          "case",
+		NMod_NPMod:    "nmod:npmod",
+		NMod_TMod:     "nmod:tmod",
+		NMod_Poss:     "nmod:poss",
+		AdvCl:         "advcl",
+		AdvMod:        "advmod",
+		Compound:      "compound",
+		Compound_Part: "compound:prt",
+		Name:          "name",
+		MWE:           "mwe",
+		Foreign:       "foreign",
+		GoesWith:      "goeswith",
+		List:          "list",
+		Dislocated:    "dislocated",
+		Parataxis:     "parataxis",
+		Remnant:       "remnant",
+		Reparandum:    "reparandum",
+		Vocative:      "vocative",
+		Discourse:     "discourse",
+		Expl:          "expl",
+		Aux:           "aux",
+		AuxPass:       "auxpass",
+		Cop:           "cop",
+		Mark:          "mark",
+		Punct:         "punct",
+		Conj:          "conj",
+		Coordination:  "cc",
+		CC_PreConj:    "cc:preconj",
+
+		// UD v2
+		Obj:            "obj",
+		Obl:            "obl",
+		Clf:            "clf",
+		Fixed:          "fixed",
+		Flat:           "flat",
+		Orphan:         "orphan",
+		NSubj_Pass:     "nsubj:pass",
+		CSubj_Pass:     "csubj:pass",
+		Aux_Pass:       "aux:pass",
+		Obl_TMod:       "obl:tmod",
+		Obl_NPMod:      "obl:npmod",
+		Obl_Agent:      "obl:agent",
+		Obl_Arg:        "obl:arg",
+		Flat_Name:      "flat:name",
+		Flat_Foreign:   "flat:foreign",
+		Det_Poss:       "det:poss",
+		Compound_Redup: "compound:redup",
+		Compound_LVC:   "compound:lvc",
+		Compound_SVC:   "compound:svc",
+		AdvMod_Emph:    "advmod:emph",
+		Expl_Pass:      "expl:pass",
+		Expl_Impers:    "expl:impers",
+	}
+
+	names := make([]string, MAXDEPTYPE)
+	coarse := make([]DependencyType, MAXDEPTYPE)
+	for dt := NoDepType; dt < MAXDEPTYPE; dt++ {
+		names[dt] = udNames[dt]
+		if names[dt] == "" {
+			names[dt] = strings.ToLower(dt.String())
+		}
+		coarse[dt] = dt
+	}
+	rs, err := RegisterRelationSet("universal", names, coarse)
+	if err != nil {
+		return nil, err
+	}
+
+	// the names of the DependencyTypes, as they are marshalled
+	for dt := NoDepType; dt < MAXDEPTYPE; dt++ {
+		rs.Alias(dt.String(), Relation{rs.ID, uint16(dt)})
+		rs.Alias(strings.ToLower(dt.String()), Relation{rs.ID, uint16(dt)})
+	}
+	rs.Alias("conj:preconj", Relation{rs.ID, uint16(CC_PreConj)}) // https://github.com/UniversalDependencies/docs/issues/221
+	return rs, nil
+}
+
+func stanfordRelations() (*RelationSet, error) {
+	rels := []struct {
+		name string
+		dt   DependencyType
+	}{
+		{"-NULL-", NoDepType},
+		{"root", Root},
+		{"dep", Dep},
+		{"aux", Aux},
+		{"auxpass", AuxPass},
+		{"cop", Cop},
+		{"arg", Dep},
+		{"agent", NMod},
+		{"comp", Dep},
+		{"acomp", XComp},
+		{"ccomp", CComp},
+		{"xcomp", XComp},
+		{"obj", DObj},
+		{"dobj", DObj},
+		{"iobj", IObj},
+		{"pobj", NMod},
+		{"subj", NSubj},
+		{"nsubj", NSubj},
+		{"nsubjpass", NSubjPass},
+		{"csubj", CSubj},
+		{"csubjpass", CSubjPass},
+		{"cc", Coordination},
+		{"conj", Conj},
+		{"expl", Expl},
+		{"mod", Dep},
+		{"amod", AMod},
+		{"appos", Appos},
+		{"advcl", AdvCl},
+		{"det", Det},
+		{"predet", Det_PreDet},
+		{"preconj", CC_PreConj},
+		{"vmod", ACl},
+		{"mwe", MWE},
+		{"mark", Mark},
+		{"advmod", AdvMod},
+		{"neg", Neg},
+		{"rcmod", ACl_RelCl},
+		{"quantmod", AdvMod},
+		{"nn", Compound},
+		{"npadvmod", NMod_NPMod},
+		{"tmod", NMod_TMod},
+		{"num", NumMod},
+		{"number", Compound},
+		{"prep", Case},
+		{"poss", NMod_Poss},
+		{"possessive", Case},
+		{"prt", Compound_Part},
+		{"parataxis", Parataxis},
+		{"goeswith", GoesWith},
+		{"punct", Punct},
+		{"ref", Dep},
+		{"sdep", Dep},
+		{"xsubj", NSubj},
+
+		// additional stuff not found in the original, but found in EWT
+		{"case", Case},
+		{"compound", Compound},
+		{"nmod", NMod},
+		{"discourse", Discourse},
+		{"nummod", NumMod},
+		{"relcl", ACl_RelCl},
+		{"nfincl", ACl},
+		{"nmod:poss", NMod_Poss},
+		{"nmod:npmod", NMod_NPMod},
+		{"vocative", Vocative},
+		{"list", List},
+		{"mwprep", MWE},
+		{"remnant", Remnant},
+		{"acl", ACl},
+		{"npmod", NMod_NPMod},
+		{"mdvod", Dep},
+		{"detmod", Det},
+
+		// found in NNParser
+		{"pcomp", CComp},
+	}
+
+	names := make([]string, len(rels))
+	coarse := make([]DependencyType, len(rels))
+	for i, r := range rels {
+		names[i], coarse[i] = r.name, r.dt
+	}
+	return RegisterRelationSet("stanford", names, coarse)
+}
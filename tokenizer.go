@@ -0,0 +1,313 @@
+package lingua
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer is a rule based tokenizer. It reads raw text from an io.Reader and emits Lexemes.
+//
+// The input is first broken into runs of whitespace and runs of non-whitespace ("chunks").
+// Whitespace runs are emitted as Space lexemes. Each chunk is then classified as a whole (URIs, e-mails, dates, times and numbers)
+// or has its leading and trailing punctuation peeled off until what remains is a word.
+//
+// Line and Col of the emitted Lexemes are zero-indexed. Col counts runes, not bytes.
+// The Span of each Lexeme has its byte and rune offsets from the start of the input, and its SpaceAfter is the whitespace that follows it.
+type Tokenizer struct {
+	r *bufio.Reader
+
+	line, col        int
+	offset, runeOffs int
+
+	queue []Lexeme
+}
+
+// NewTokenizer creates a new *Tokenizer that reads from r.
+func NewTokenizer(r io.Reader) *Tokenizer {
+	return &Tokenizer{
+		r: bufio.NewReader(r),
+	}
+}
+
+// Next returns the next Lexeme in the input. When the input has been exhausted, an EOF Lexeme and io.EOF is returned.
+func (t *Tokenizer) Next() (Lexeme, error) {
+	for len(t.queue) == 0 {
+		if err := t.fill(); err != nil {
+			eof := MakeLexeme("", EOF)
+			eof.Line = t.line
+			eof.Col = t.col
+			return eof, err
+		}
+	}
+
+	retVal := t.queue[0]
+	t.queue = t.queue[1:]
+	return retVal, nil
+}
+
+// Lexemes reads the rest of the input and returns all the Lexemes found, Space lexemes included.
+func (t *Tokenizer) Lexemes() (LexemeSentence, error) {
+	retVal := NewLexemeSentence()
+	for {
+		lex, err := t.Next()
+		if err == io.EOF {
+			return retVal, nil
+		}
+		if err != nil {
+			return retVal, err
+		}
+		retVal = append(retVal, lex)
+	}
+}
+
+// Tokenize is a convenience function to tokenize a string. Space lexemes are not returned.
+func Tokenize(s string) LexemeSentence {
+	lexemes, _ := NewTokenizer(strings.NewReader(s)).Lexemes() // reading from a string never fails
+	retVal := lexemes[:0]
+	for _, lex := range lexemes {
+		if lex.LexemeType != Space {
+			retVal = append(retVal, lex)
+		}
+	}
+	return retVal
+}
+
+// fill reads the next whitespace run or chunk and queues up the lexemes found in it.
+func (t *Tokenizer) fill() error {
+	r, size, err := t.r.ReadRune()
+	if err != nil {
+		return err
+	}
+
+	line, col := t.line, t.col
+	offset, runeOffs := t.offset, t.runeOffs
+	space := unicode.IsSpace(r)
+
+	var buf bytes.Buffer
+	offsets := []int{offset} // offsets[i] is the byte offset of the ith rune of the run. Invalid UTF-8 makes it differ from buf.
+	for {
+		buf.WriteRune(r)
+		t.advance(r, size)
+		offsets = append(offsets, t.offset)
+
+		if r, size, err = t.r.ReadRune(); err != nil {
+			break
+		}
+		if unicode.IsSpace(r) != space {
+			t.r.UnreadRune()
+			break
+		}
+	}
+	if err != nil && err != io.EOF {
+		return err
+	}
+
+	if space {
+		lex := MakeLexeme(buf.String(), Space)
+		lex.Line = line
+		lex.Col = col
+		lex.Span = Span{offset, t.offset, runeOffs, t.runeOffs}
+		t.queue = append(t.queue, lex)
+		return nil
+	}
+
+	for _, lex := range splitChunk(buf.String()) {
+		start, end := lex.Col, lex.Col+utf8.RuneCountInString(lex.Value)
+		lex.Span = Span{offsets[start], offsets[end], runeOffs + start, runeOffs + end}
+		lex.Line = line
+		lex.Col += col
+		t.queue = append(t.queue, lex)
+	}
+
+	// a chunk that didn't end the input is followed by whitespace, which is the SpaceAfter of its last lexeme
+	if err == nil {
+		last := len(t.queue) - 1
+		if err = t.fill(); err != nil {
+			return err
+		}
+		t.queue[last].SpaceAfter = t.queue[last+1].Value
+	}
+	return nil
+}
+
+func (t *Tokenizer) advance(r rune, size int) {
+	t.offset += size
+	t.runeOffs++
+	if r == '\n' {
+		t.line++
+		t.col = 0
+		return
+	}
+	t.col++
+}
+
+/* Classification */
+
+var (
+	uriRegexp    = regexp.MustCompile(`^(?i)(?:(?:(?:https?|ftp)://|www\.)[^\s<>"]*[^\s<>"().,;:!?'\[\]{}]|[a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)*\.(?:com|net|org|edu|gov|info|biz|io|id|co|me|sg|my|uk|au|us)(?:/[^\s<>"]*[^\s<>"().,;:!?'\[\]{}])?/?)$`)
+	emailRegexp  = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+	dateRegexp   = regexp.MustCompile(`^(?:\d{1,2}[/.-]\d{1,2}[/.-](?:\d{4}|\d{2})|\d{4}[/-]\d{1,2}[/-]\d{1,2})$`)
+	timeRegexp   = regexp.MustCompile(`^(?:[01]?\d|2[0-4]):[0-5]\d(?::[0-5]\d)?$`)
+	numberRegexp = regexp.MustCompile(`^[+-]?(?:\d{1,3}(?:\.\d{3})+(?:,\d+)?|\d{1,3}(?:,\d{3})+(?:\.\d+)?|\d+(?:[.,]\d+)?)$`)
+
+	// currencyRegexp matches currencies written before the amount without a space, e.g. Rp10.000
+	currencyRegexp = regexp.MustCompile(`^(?:Rp\.?|IDR|USD|US\$)\d`)
+)
+
+// classifySpecial checks if the string as a whole is one of the special lexeme types.
+// The order matters: e-mails look like URIs, and dates like 12.05.2019 have to be checked before numbers.
+func classifySpecial(s string) (LexemeType, bool) {
+	switch {
+	case emailRegexp.MatchString(s):
+		return URI, true
+	case uriRegexp.MatchString(s):
+		return URI, true
+	case dateRegexp.MatchString(s):
+		return Date, true
+	case timeRegexp.MatchString(s):
+		return Time, true
+	case numberRegexp.MatchString(s):
+		return Number, true
+	}
+	return Word, false
+}
+
+func runeType(r rune) LexemeType {
+	switch {
+	case unicode.IsPunct(r):
+		return Punctuation
+	case unicode.IsSymbol(r):
+		return Symbol
+	case unicode.IsSpace(r):
+		return Space
+	}
+	return Word
+}
+
+func isWordRune(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) }
+
+// isJoiner returns true if the rune may appear inside a word when it's surrounded by word runes.
+// e.g. anak-anak, don't, e.g
+func isJoiner(r rune) bool {
+	switch r {
+	case '-', '\'', '’', '.', '_':
+		return true
+	}
+	return false
+}
+
+func isAffix(r rune) bool { return unicode.IsPunct(r) || unicode.IsSymbol(r) }
+
+type piece struct {
+	start int // rune offset within the chunk
+	runes []rune
+}
+
+func (p piece) lexeme(t LexemeType) Lexeme {
+	lex := MakeLexeme(string(p.runes), t)
+	lex.Col = p.start
+	return lex
+}
+
+// splitChunk splits a chunk (a string without whitespace) into its lexemes. The Col of each lexeme is the rune offset in the chunk.
+func splitChunk(chunk string) (retVal []Lexeme) {
+	rs := []rune(chunk)
+	lo, hi := 0, len(rs)
+
+	var suffixes []Lexeme
+	for lo < hi {
+		p := piece{lo, rs[lo:hi]}
+		if t, ok := classifySpecial(string(p.runes)); ok {
+			retVal = append(retVal, p.lexeme(t))
+			break
+		}
+
+		// prefixes
+		if loc := currencyRegexp.FindStringIndex(string(p.runes)); loc != nil {
+			n := utf8.RuneCountInString(string(p.runes)[:loc[1]]) - 1 // don't take the digit
+			retVal = append(retVal, piece{lo, rs[lo : lo+n]}.lexeme(Symbol))
+			lo += n
+			continue
+		}
+		if r := rs[lo]; isAffix(r) {
+			n := dots(rs[lo:hi], true)
+			retVal = append(retVal, piece{lo, rs[lo : lo+n]}.lexeme(runeType(r)))
+			lo += n
+			continue
+		}
+
+		// suffixes
+		if r := rs[hi-1]; isAffix(r) {
+			n := dots(rs[lo:hi], false)
+			suffixes = append(suffixes, piece{hi - n, rs[hi-n : hi]}.lexeme(runeType(r)))
+			hi -= n
+			continue
+		}
+
+		retVal = append(retVal, splitCore(p)...)
+		break
+	}
+
+	for i := len(suffixes) - 1; i >= 0; i-- {
+		retVal = append(retVal, suffixes[i])
+	}
+	return retVal
+}
+
+// dots returns the length of the run of full stops at the start (or end) of rs, so that ellipses are kept whole.
+// If there isn't a full stop, 1 is returned.
+func dots(rs []rune, fromStart bool) int {
+	n := 0
+	for i := range rs {
+		j := i
+		if !fromStart {
+			j = len(rs) - 1 - i
+		}
+		if rs[j] != '.' {
+			break
+		}
+		n++
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// splitCore splits what is left of a chunk after its prefixes and suffixes have been removed.
+// Punctuation and symbols are split off unless they're joiners in between word runes.
+func splitCore(p piece) (retVal []Lexeme) {
+	rs := p.runes
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		lex := piece{p.start + start, rs[start:end]}.lexeme(Word)
+		retVal = append(retVal, lex.Fix())
+		start = -1
+	}
+
+	for i, r := range rs {
+		switch {
+		case isWordRune(r):
+			if start < 0 {
+				start = i
+			}
+		case isJoiner(r) && start >= 0 && i+1 < len(rs) && isWordRune(rs[i+1]):
+			// part of the word
+		case r == '²' && start >= 0:
+			// informal reduplication: anak²
+		default:
+			flush(i)
+			retVal = append(retVal, piece{p.start + i, rs[i : i+1]}.lexeme(runeType(r)))
+		}
+	}
+	flush(len(rs))
+	return retVal
+}
@@ -0,0 +1,108 @@
+package lingua
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var tokenizeTests = []struct {
+	input  string
+	values []string
+	types  []LexemeType
+}{
+	{"Halo, dunia!", []string{"Halo", ",", "dunia", "!"}, []LexemeType{Word, Punctuation, Word, Punctuation}},
+	{"Harganya Rp1.250.000,50 saja.", []string{"Harganya", "Rp", "1.250.000,50", "saja", "."}, []LexemeType{Word, Symbol, Number, Word, Punctuation}},
+	{"Harganya 1.250.000,50 saja.", []string{"Harganya", "1.250.000,50", "saja", "."}, []LexemeType{Word, Number, Word, Punctuation}},
+	{"It costs $1,250.50.", []string{"It", "costs", "$", "1,250.50", "."}, []LexemeType{Word, Word, Symbol, Number, Punctuation}},
+	{"Kunjungi https://example.co.id/berita?id=1.", []string{"Kunjungi", "https://example.co.id/berita?id=1", "."}, []LexemeType{Word, URI, Punctuation}},
+	{"(email: budi@example.com)", []string{"(", "email", ":", "budi@example.com", ")"}, []LexemeType{Punctuation, Word, Punctuation, URI, Punctuation}},
+	{"Rapat pada 17/08/1945 pukul 10:30.", []string{"Rapat", "pada", "17/08/1945", "pukul", "10:30", "."}, []LexemeType{Word, Word, Date, Word, Time, Punctuation}},
+	{"anak-anak, 50% dan/atau...", []string{"anak-anak", ",", "50", "%", "dan", "/", "atau", "..."}, []LexemeType{Word, Punctuation, Number, Punctuation, Word, Punctuation, Word, Punctuation}},
+	{"\"Don't,\" katanya.", []string{"\"", "Don't", ",", "\"", "katanya", "."}, nil},
+	{"e.g. dr. Budi", []string{"e.g", ".", "dr", ".", "Budi"}, nil},
+	{"Café “naïve” — 5€.", []string{"Café", "“", "naïve", "”", "—", "5", "€", "."}, nil},
+}
+
+func TestTokenize(t *testing.T) {
+	assert := assert.New(t)
+	for _, tt := range tokenizeTests {
+		lexemes := Tokenize(tt.input)
+
+		values := make([]string, len(lexemes))
+		types := make([]LexemeType, len(lexemes))
+		runes := []rune(tt.input)
+		for i, lex := range lexemes {
+			values[i] = lex.Value
+			types[i] = lex.LexemeType
+
+			assert.Equal(lex.Value, tt.input[lex.Span.Start:lex.Span.End], "Byte span of %q", lex.Value)
+			assert.Equal(lex.Value, string(runes[lex.Span.RuneStart:lex.Span.RuneEnd]), "Rune span of %q", lex.Value)
+		}
+
+		assert.Equal(tt.values, values, "Input %q", tt.input)
+		if tt.types != nil {
+			assert.Equal(tt.types, types, "Input %q", tt.input)
+		}
+	}
+}
+
+func TestTokenizer_Positions(t *testing.T) {
+	assert := assert.New(t)
+	tok := NewTokenizer(strings.NewReader("Halo  dunia.\nApa kabar?"))
+
+	var lexemes LexemeSentence
+	for {
+		lex, err := tok.Next()
+		if err == io.EOF {
+			assert.Equal(EOF, lex.LexemeType)
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		lexemes = append(lexemes, lex)
+	}
+
+	expected := []struct {
+		value     string
+		t         LexemeType
+		line, col int
+	}{
+		{"Halo", Word, 0, 0},
+		{"  ", Space, 0, 4},
+		{"dunia", Word, 0, 6},
+		{".", Punctuation, 0, 11},
+		{"\n", Space, 0, 12},
+		{"Apa", Word, 1, 0},
+		{" ", Space, 1, 3},
+		{"kabar", Word, 1, 4},
+		{"?", Punctuation, 1, 9},
+	}
+
+	if !assert.Equal(len(expected), len(lexemes)) {
+		t.FailNow()
+	}
+	for i, e := range expected {
+		lex := lexemes[i]
+		assert.Equal(e.value, lex.Value)
+		assert.Equal(e.t, lex.LexemeType, "%q", lex.Value)
+		assert.Equal(e.line, lex.Line, "Line of %q", lex.Value)
+		assert.Equal(e.col, lex.Col, "Col of %q", lex.Value)
+	}
+}
+
+func TestTokenize_Flags(t *testing.T) {
+	lexemes := Tokenize("budi@example.com 1.000")
+	if lexemes[0].Flags()&(1<<LikeEmail) == 0 {
+		t.Errorf("Expected %q to be LikeEmail", lexemes[0].Value)
+	}
+	if lexemes[1].Flags()&(1<<LikeNum) == 0 {
+		t.Errorf("Expected %q to be LikeNum", lexemes[1].Value)
+	}
+	if lexemes[0].Shape() != Shape("URI") {
+		t.Errorf("Expected the shape of %q to be URI. Got %q instead", lexemes[0].Value, lexemes[0].Shape())
+	}
+}
@@ -0,0 +1,490 @@
+package treebank
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+)
+
+// ParseError is an error found while reading a treebank. Line is 1-indexed.
+type ParseError struct {
+	Line int
+	Msg  string
+}
+
+func (err *ParseError) Error() string { return fmt.Sprintf("Line %d: %s", err.Line, err.Msg) }
+
+func parseErrorf(line int, format string, args ...interface{}) error {
+	return &ParseError{Line: line, Msg: fmt.Sprintf(format, args...)}
+}
+
+// MultiWord is a multiword token of a CONLLU file: a range of words that is a single token in the text (e.g. "berdirinya" = "berdiri" + "nya").
+// Start and End are the (1-indexed) IDs of the first and last words of the range.
+type MultiWord struct {
+	Start, End int
+	Form       string
+	Misc       string
+}
+
+// EmptyNode is an empty node of a CONLLU file (e.g. "8.1"), used to represent elided words in the enhanced dependencies.
+// After is the ID of the word that precedes the empty node, and Index is the number after the dot. The rest of the columns are kept as they are.
+type EmptyNode struct {
+	After, Index int
+
+	Form, Lemma, UPOS, XPOS, Feats, Deps, Misc string
+}
+
+// ID returns the ID of the empty node, as written in a CONLLU file
+func (n EmptyNode) ID() string { return fmt.Sprintf("%d.%d", n.After, n.Index) }
+
+// annotation creates the Annotation of the empty node, for an EnhancedGraph
+func (n EmptyNode) annotation(ts *lingua.TagSet, f lingua.AnnotationFixer) (*lingua.Annotation, error) {
+	tag := n.UPOS
+	if tagColumn(ts) == 4 {
+		tag = n.XPOS
+	}
+	t, _ := stringToPOSTag(ts, tag)
+	feats, err := lingua.ParseFeatures(n.Feats)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Empty node %v", n.ID())
+	}
+
+	a := lingua.NewAnnotation()
+	a.Lexeme = lingua.Lexeme{
+		Value:      n.Form,
+		LexemeType: lexTypeOf(t),
+		Line:       -1,
+		Col:        -1,
+	}
+	a.POSTag = t
+	a.DependencyType = lingua.NoDepType
+	a.ID = -1 // empty nodes are not part of the basic tree
+	if err = a.Process(f); err != nil {
+		return nil, err
+	}
+	if n.Lemma != "_" {
+		a.Lemma = n.Lemma
+	}
+	a.Features = feats
+	return a, nil
+}
+
+// emptyNodeOf creates an EmptyNode from the Annotation of an empty node of an EnhancedGraph
+func emptyNodeOf(ts *lingua.TagSet, id lingua.NodeID, a *lingua.Annotation) EmptyNode {
+	n := EmptyNode{
+		After: id.Word,
+		Index: id.Empty,
+		Form:  a.Value,
+		Lemma: or(a.Lemma),
+		UPOS:  "_",
+		XPOS:  "_",
+		Feats: or(a.Features.String()),
+		Misc:  "_",
+	}
+	if tagColumn(ts) == 4 {
+		n.XPOS = posTagToString(ts, a.POSTag)
+	} else {
+		n.UPOS = posTagToString(ts, a.POSTag)
+	}
+	return n
+}
+
+type conlluOpt func(*conlluReader)
+
+// Lenient makes the CONLLU reader tolerate irregularities it can recover from: missing or extra columns,
+// and comments in the middle of a sentence. Sentences it can't recover from (e.g. a HEAD that is not a number) are skipped.
+// report, if not nil, is called with the error of each skipped sentence.
+func Lenient(report func(error)) conlluOpt {
+	return func(r *conlluReader) {
+		r.lenient = true
+		r.report = report
+	}
+}
+
+// WithTagSet sets the TagSet of the tags. The tags of lingua.UniversalTags are read from the UPOS column,
+// and the tags of any other TagSet (e.g. lingua.PennTags) from the XPOS column. The default is lingua.UniversalTags.
+func WithTagSet(ts *lingua.TagSet) conlluOpt {
+	return func(r *conlluReader) {
+		r.tagSet = ts
+	}
+}
+
+// WithRelationSet sets the RelationSet of the DEPREL column. The default is lingua.UniversalRelations.
+func WithRelationSet(rs *lingua.RelationSet) conlluOpt {
+	return func(r *conlluReader) {
+		r.relationSet = rs
+	}
+}
+
+// Columns is the layout of the columns of a tabular treebank file.
+type Columns byte
+
+const (
+	ConlluColumns  Columns = iota // ID FORM LEMMA UPOS XPOS FEATS HEAD DEPREL DEPS MISC
+	ConllXColumns                 // ID FORM LEMMA CPOSTAG POSTAG FEATS HEAD DEPREL PHEAD PDEPREL
+	Conll09Columns                // ID FORM LEMMA PLEMMA POS PPOS FEAT PFEAT HEAD PHEAD DEPREL PDEPREL FILLPRED PRED APRED...
+	TSVColumns                    // FORM TAG
+)
+
+// WithColumns sets the layout of the columns. The default is ConlluColumns.
+//
+// The columns of the other layouts are read as the CONLLU columns they correspond to, so the SentenceTags are written as CONLLU by WriteConllu:
+//		CoNLL-X: CPOSTAG and POSTAG are the UPOS and XPOS. PHEAD and PDEPREL are dropped.
+//		CoNLL-2009: POS is the UPOS for lingua.UniversalTags, and the XPOS for any other TagSet. The columns of the semantic roles are dropped.
+//		TSV: the tag is the UPOS or the XPOS, as in CoNLL-2009. The words have no dependencies: their HEAD and DEPREL are _ (see SentenceTag.HasTree).
+// FEATS that aren't Name=Value pairs (as in some CoNLL-X treebanks) are dropped. Only CONLLU files have comments.
+func WithColumns(c Columns) conlluOpt {
+	return func(r *conlluReader) {
+		r.columns = c
+	}
+}
+
+// PredictedColumns makes the reader read the predicted columns of CoNLL-X (PHEAD and PDEPREL) and CoNLL-2009 files
+// (PLEMMA, PPOS, PFEAT, PHEAD and PDEPREL) instead of the gold columns.
+func PredictedColumns() conlluOpt {
+	return func(r *conlluReader) {
+		r.predicted = true
+	}
+}
+
+// tagColumn returns the column the tags of the TagSet are in
+func tagColumn(ts *lingua.TagSet) int {
+	if ts == nil || ts == lingua.UniversalTags {
+		return 3
+	}
+	return 4
+}
+
+// conlluReader reads SentenceTags, one at a time, from a CONLLU file. By default it is strict, and returns the first error it finds.
+type conlluReader struct {
+	s    *bufio.Scanner
+	line int
+
+	lenient bool
+	report  func(error)
+
+	columns   Columns
+	predicted bool
+
+	tagSet      *lingua.TagSet
+	relationSet *lingua.RelationSet
+
+	sentenceCount int
+	ended         bool // whether the last sentence read was read to its end
+}
+
+func newConlluReader(r io.Reader, opts ...conlluOpt) *conlluReader {
+	cr := &conlluReader{
+		s:           bufio.NewScanner(r),
+		tagSet:      lingua.UniversalTags,
+		relationSet: lingua.UniversalRelations,
+	}
+	cr.s.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for _, opt := range opts {
+		opt(cr)
+	}
+	return cr
+}
+
+// next reads the next sentence. io.EOF is returned when there are no more sentences.
+func (r *conlluReader) next() (SentenceTag, error) {
+	for {
+		st, err := r.sentence()
+		if err == nil || err == io.EOF {
+			return st, err
+		}
+		if _, ok := err.(*ParseError); !ok || !r.lenient {
+			return st, err
+		}
+
+		if r.report != nil {
+			r.report(err)
+		}
+		r.skip()
+	}
+}
+
+// skip skips the rest of the current sentence
+func (r *conlluReader) skip() {
+	if r.ended {
+		return
+	}
+	for r.s.Scan() {
+		r.line++
+		if strings.TrimSpace(r.s.Text()) == "" {
+			return
+		}
+	}
+}
+
+func (r *conlluReader) sentence() (SentenceTag, error) {
+	st := SentenceTag{
+		TagSet:      r.tagSet,
+		RelationSet: r.relationSet,
+	}
+	var startLine int
+	r.ended = false
+
+	for r.s.Scan() {
+		r.line++
+		l := strings.TrimRight(r.s.Text(), "\r")
+
+		if strings.TrimSpace(l) == "" {
+			if len(st.Sentence) == 0 {
+				// runs of empty lines, or comments without a sentence
+				st.Comments = nil
+				continue
+			}
+			break
+		}
+		if startLine == 0 {
+			startLine = r.line
+		}
+
+		if r.columns == ConlluColumns && strings.HasPrefix(l, "#") {
+			if (len(st.Sentence) > 0 || len(st.MultiWords) > 0 || len(st.EmptyNodes) > 0) && !r.lenient {
+				return st, parseErrorf(r.line, "Comment in the middle of a sentence")
+			}
+			st.Comments = append(st.Comments, l)
+			continue
+		}
+
+		cols, err := r.conlluColumns(&st, strings.Split(l, "\t"))
+		if err != nil {
+			return st, err
+		}
+		if err = r.token(&st, cols); err != nil {
+			return st, err
+		}
+	}
+	r.ended = true
+	if err := r.s.Err(); err != nil {
+		return st, errors.Wrapf(err, "Line %d", r.line)
+	}
+	if len(st.Sentence) == 0 {
+		return st, io.EOF
+	}
+
+	if err := st.check(); err != nil {
+		return st, parseErrorf(startLine, "%v", err)
+	}
+	r.sentenceCount++
+	return st, nil
+}
+
+// conlluColumns checks the number of columns of a line, and rearranges them as the columns of a CONLLU line
+func (r *conlluReader) conlluColumns(st *SentenceTag, cols []string) ([]string, error) {
+	var p int // the offset of the predicted columns
+	if r.predicted {
+		p = 1
+	}
+
+	switch r.columns {
+	case ConllXColumns:
+		if cols = r.pad(cols, 10); len(cols) != 10 {
+			return nil, parseErrorf(r.line, "Expected 10 columns. Got %d instead", len(cols))
+		}
+		retVal := []string{cols[0], cols[1], cols[2], cols[3], cols[4], conlluFeats(cols[5]), cols[6], cols[7], "_", "_"}
+		if r.predicted {
+			retVal[6], retVal[7] = cols[8], cols[9]
+		}
+		return retVal, nil
+
+	case Conll09Columns:
+		if len(cols) < 12 {
+			return nil, parseErrorf(r.line, "Expected at least 12 columns. Got %d instead", len(cols))
+		}
+		retVal := []string{cols[0], cols[1], cols[2+p], "_", "_", conlluFeats(cols[6+p]), cols[8+p], cols[10+p], "_", "_"}
+		retVal[tagColumn(r.tagSet)] = cols[4+p]
+		return retVal, nil
+
+	case TSVColumns:
+		if r.lenient && len(cols) > 2 {
+			cols = cols[:2]
+		}
+		if len(cols) != 2 {
+			return nil, parseErrorf(r.line, "Expected 2 columns. Got %d instead", len(cols))
+		}
+		retVal := []string{strconv.Itoa(len(st.Sentence) + 1), cols[0], "_", "_", "_", "_", "_", "_", "_", "_"}
+		retVal[tagColumn(r.tagSet)] = cols[1]
+		return retVal, nil
+	}
+
+	if cols = r.pad(cols, 10); len(cols) != 10 {
+		return nil, parseErrorf(r.line, "Expected 10 columns. Got %d instead", len(cols))
+	}
+	return cols, nil
+}
+
+// pad pads or truncates the columns of a lenient reader, if there are at least 8 columns
+func (r *conlluReader) pad(cols []string, n int) []string {
+	switch {
+	case !r.lenient:
+	case len(cols) > n:
+		cols = cols[:n]
+	case len(cols) >= 8:
+		for len(cols) < n {
+			cols = append(cols, "_")
+		}
+	}
+	return cols
+}
+
+// conlluFeats returns the FEATS column if it can be read as CONLLU features, or "_"
+func conlluFeats(col string) string {
+	if _, err := lingua.ParseFeatures(col); err != nil {
+		return "_"
+	}
+	return col
+}
+
+// token parses a token line, and adds it to the sentence
+func (r *conlluReader) token(st *SentenceTag, cols []string) error {
+	id := cols[0]
+	n := len(st.Sentence)
+
+	switch {
+	case strings.Contains(id, "-"):
+		rng := strings.SplitN(id, "-", 2)
+		start, err1 := strconv.Atoi(rng[0])
+		end, err2 := strconv.Atoi(rng[1])
+		if err1 != nil || err2 != nil || end <= start {
+			return parseErrorf(r.line, "Invalid multiword token range %q", id)
+		}
+		if start != n+1 {
+			return parseErrorf(r.line, "Expected the multiword token range %q to start at %d", id, n+1)
+		}
+		st.MultiWords = append(st.MultiWords, MultiWord{
+			Start: start,
+			End:   end,
+			Form:  lingua.UnescapeSpecials(cols[1]),
+			Misc:  cols[9],
+		})
+		return nil
+
+	case strings.Contains(id, "."):
+		dec := strings.SplitN(id, ".", 2)
+		after, err1 := strconv.Atoi(dec[0])
+		index, err2 := strconv.Atoi(dec[1])
+		if err1 != nil || err2 != nil || index < 1 {
+			return parseErrorf(r.line, "Invalid empty node ID %q", id)
+		}
+		if after != n {
+			return parseErrorf(r.line, "Expected the empty node %q to follow word %d", id, n)
+		}
+		if _, err := lingua.ParseDeps(lingua.NodeID{Word: after, Empty: index}, cols[8]); err != nil {
+			return parseErrorf(r.line, "%v", err)
+		}
+		st.EmptyNodes = append(st.EmptyNodes, EmptyNode{
+			After: after,
+			Index: index,
+			Form:  lingua.UnescapeSpecials(cols[1]),
+			Lemma: cols[2],
+			UPOS:  cols[3],
+			XPOS:  cols[4],
+			Feats: cols[5],
+			Deps:  cols[8],
+			Misc:  cols[9],
+		})
+		return nil
+	}
+
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return parseErrorf(r.line, "Invalid ID %q", id)
+	}
+	if i != n+1 {
+		return parseErrorf(r.line, "Expected ID %d. Got %d instead", n+1, i)
+	}
+
+	tag := cols[tagColumn(r.tagSet)]
+
+	h := -1 // an unknown head
+	if cols[6] != "_" {
+		if h, err = strconv.Atoi(cols[6]); err != nil {
+			return parseErrorf(r.line, "Invalid HEAD %q", cols[6])
+		}
+	}
+
+	feats, err := lingua.ParseFeatures(cols[5])
+	if err != nil {
+		return parseErrorf(r.line, "%v", err)
+	}
+
+	if _, err = lingua.ParseDeps(lingua.WordNode(i), cols[8]); err != nil {
+		return parseErrorf(r.line, "%v", err)
+	}
+
+	t, _ := stringToPOSTag(r.tagSet, tag)
+	dt, _ := stringToDependencyType(r.relationSet, cols[7])
+
+	lexeme := lingua.Lexeme{
+		Value:      lingua.UnescapeSpecials(cols[1]),
+		LexemeType: lexTypeOf(t),
+		Line:       r.sentenceCount,
+		Col:        r.line - 1,
+		SpaceAfter: " ",
+	}
+	if noSpaceAfter(cols[9]) {
+		lexeme.SpaceAfter = ""
+	}
+	// the words of a multiword token are written together; what follows the token is in the MISC of the multiword token
+	if k := len(st.MultiWords) - 1; k >= 0 && st.MultiWords[k].End >= i {
+		lexeme.SpaceAfter = ""
+		if st.MultiWords[k].End == i && !noSpaceAfter(st.MultiWords[k].Misc) {
+			lexeme.SpaceAfter = " "
+		}
+	}
+
+	st.Sentence = append(st.Sentence, lexeme)
+	st.Tags = append(st.Tags, t)
+	st.Heads = append(st.Heads, h)
+	st.Labels = append(st.Labels, dt)
+	st.Lemmas = append(st.Lemmas, colValue(cols[2]))
+	st.UPOS = append(st.UPOS, colValue(cols[3]))
+	st.XPOS = append(st.XPOS, colValue(cols[4]))
+	st.Features = append(st.Features, feats)
+	st.Deps = append(st.Deps, cols[8])
+	st.Misc = append(st.Misc, colValue(cols[9]))
+	st.columns = append(st.columns, cols)
+	return nil
+}
+
+// check checks that the heads and the multiword tokens are within the sentence
+func (st *SentenceTag) check() error {
+	n := len(st.Sentence)
+	for i, h := range st.Heads {
+		if h < -1 || h > n {
+			return errors.Errorf("HEAD %d of word %d is out of range", h, i+1)
+		}
+	}
+	for _, mw := range st.MultiWords {
+		if mw.End > n {
+			return errors.Errorf("Multiword token %d-%d is out of range", mw.Start, mw.End)
+		}
+	}
+	return nil
+}
+
+// colValue returns the value of a column. "_" is an empty value
+func colValue(col string) string {
+	if col == "_" {
+		return ""
+	}
+	return col
+}
+
+// noSpaceAfter checks if the MISC column has SpaceAfter=No
+func noSpaceAfter(misc string) bool {
+	for _, kv := range strings.Split(misc, "|") {
+		if kv == "SpaceAfter=No" {
+			return true
+		}
+	}
+	return false
+}
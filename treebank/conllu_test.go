@@ -0,0 +1,299 @@
+package treebank
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+const udv2Conllu = `# newdoc id = test
+# sent_id = test-s1
+# text = Gedung itu berdirinya di pusat kota.
+1	Gedung	gedung	NOUN	NSD	_	3	nsubj	_	_
+2	itu	itu	DET	B--	_	1	det	_	_
+3-4	berdirinya	_	_	_	_	_	_	_	_
+3	berdiri	berdiri	VERB	VSA	_	0	root	_	_
+4	nya	dia	PRON	PS3	PronType=Prs|Person=3|Number=Sing	3	nmod	_	_
+5	di	di	ADP	R--	_	6	case	_	_
+6	pusat	pusat	NOUN	NSD	_	3	obl	_	_
+7	kota	kota	NOUN	NSD	_	6	compound	_	SpaceAfter=No
+8	.	.	PUNCT	Z--	_	3	punct	_	_
+
+# sent_id = test-s2
+# text = Ani makan, Budi juga.
+1	Ani	Ani	PROPN	X--	_	2	nsubj	2:nsubj	_
+2	makan	makan	VERB	VSA	Voice=Act	0	root	0:root	SpaceAfter=No
+3	,	,	PUNCT	Z--	_	4	punct	4:punct	_
+4	Budi	Budi	PROPN	X--	_	2	conj	4.1:nsubj	_
+4.1	makan	makan	VERB	VSA	_	_	_	2:conj	_
+5	juga	juga	ADV	D--	_	4	advmod	4.1:advmod	SpaceAfter=No
+6	.	.	PUNCT	Z--	_	2	punct	2:punct	_`
+
+func TestReadConllu_UDv2(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(udv2Conllu))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !assert.Equal(2, len(sentences), "the last sentence doesn't need a trailing empty line") {
+		t.FailNow()
+	}
+
+	s1 := sentences[0]
+	assert.Equal([]string{"# newdoc id = test", "# sent_id = test-s1", "# text = Gedung itu berdirinya di pusat kota."}, s1.Comments)
+	assert.Equal("test-s1", s1.ID())
+	assert.Equal("Gedung itu berdirinya di pusat kota.", s1.Text())
+	id, ok := s1.Comment("newdoc id")
+	assert.True(ok)
+	assert.Equal("test", id)
+
+	assert.Equal(8, len(s1.Sentence))
+	assert.Equal([]int{3, 1, 0, 3, 6, 3, 6, 3}, s1.Heads)
+	assert.Equal([]MultiWord{{Start: 3, End: 4, Form: "berdirinya", Misc: "_"}}, s1.MultiWords)
+	assert.Equal("Number=Sing|Person=3|PronType=Prs", s1.Features[3].String())
+	assert.Nil(s1.Features[0])
+	assert.Equal([]string{"gedung", "itu", "berdiri", "dia", "di", "pusat", "kota", "."}, s1.Lemmas)
+	assert.Equal([]string{"NOUN", "DET", "VERB", "PRON", "ADP", "NOUN", "NOUN", "PUNCT"}, s1.UPOS)
+	assert.Equal([]string{"NSD", "B--", "VSA", "PS3", "R--", "NSD", "NSD", "Z--"}, s1.XPOS)
+	assert.Equal([]string{"", "", "", "", "", "", "SpaceAfter=No", ""}, s1.Misc)
+	assert.Equal("dia", s1.AnnotatedSentence(nil)[4].Lemma, "the lemmas of the treebank are used")
+	assert.Equal(s1.Text(), s1.Sentence.Detokenize()[:len(s1.Text())], "SpaceAfter is read from the MISC column and the multiword tokens")
+
+	s2 := sentences[1]
+	assert.Equal(6, len(s2.Sentence))
+	if assert.Equal(1, len(s2.EmptyNodes)) {
+		n := s2.EmptyNodes[0]
+		assert.Equal("4.1", n.ID())
+		assert.Equal("makan", n.Form)
+		assert.Equal("2:conj", n.Deps)
+	}
+	assert.Equal(s2.Text(), strings.TrimSpace(s2.Sentence.Detokenize()))
+}
+
+func TestReadConllu_Errors(t *testing.T) {
+	tests := []struct {
+		name, conllu string
+		line         int
+	}{
+		{"head", "# sent_id = 1\n1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n2\tB\tb\tX\tX\t_\tx\tdep\t_\t_\n", 3},
+		{"columns", "1\tA\ta\tX\tX\t_\t0\troot\t_\n", 1},
+		{"id", "1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n3\tB\tb\tX\tX\t_\t1\tdep\t_\t_\n", 2},
+		{"range", "1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n\n1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n3-2\tBC\t_\t_\t_\t_\t_\t_\t_\t_\n", 4},
+		{"out of range", "1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n2\tB\tb\tX\tX\t_\t5\tdep\t_\t_\n", 1},
+		{"features", "1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n2\tB\tb\tX\tX\tNumber\t1\tdep\t_\t_\n", 2},
+		{"deps", "1\tA\ta\tX\tX\t_\t0\troot\t0:root\t_\n2\tB\tb\tX\tX\t_\t1\tdep\t1\t_\n", 2},
+		{"comment", "1\tA\ta\tX\tX\t_\t0\troot\t_\t_\n# oops\n", 2},
+	}
+	for _, tt := range tests {
+		sentences, err := ReadConllu(strings.NewReader(tt.conllu))
+		perr, ok := err.(*ParseError)
+		if !ok {
+			t.Errorf("%v: expected a *ParseError. Got %v instead", tt.name, err)
+			continue
+		}
+		if perr.Line != tt.line {
+			t.Errorf("%v: expected the error to be on line %d. Got %v instead", tt.name, tt.line, perr)
+		}
+		if tt.name == "range" && len(sentences) != 1 {
+			t.Errorf("%v: expected the sentences before the error to be returned", tt.name)
+		}
+	}
+}
+
+func TestReadConllu_Lenient(t *testing.T) {
+	conllu := "1\tA\ta\tX\tX\t_\t0\troot\t_\n" + // missing a column
+		"2\tB\tb\tX\tX\t_\t1\tdep\t_\t_\t\n" + // an extra column
+		"\n" +
+		"1\tC\tc\tX\tX\t_\tx\troot\t_\t_\n" + // bad head: skipped
+		"2\tD\td\tX\tX\t_\t1\tdep\t_\t_\n" +
+		"\n" +
+		"1\tE\te\tX\tX\t_\t0\troot\t_\t_\n" +
+		"2\tF\tf\tX\tX\t_\t9\tdep\t_\t_\n" + // out of range: skipped
+		"\n" +
+		"1\tG\tg\tX\tX\t_\t0\troot\t_\t_\n"
+
+	var skipped []error
+	sentences, err := ReadConllu(strings.NewReader(conllu), Lenient(func(err error) { skipped = append(skipped, err) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sentences) != 2 || sentences[0].String() != "A B" || sentences[1].String() != "G" {
+		t.Errorf("Unexpected sentences: %v", sentences)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("Expected 2 sentences to be skipped. Got %v", skipped)
+	}
+	if skipped[0].(*ParseError).Line != 4 || skipped[1].(*ParseError).Line != 7 {
+		t.Errorf("Unexpected lines of the skipped sentences: %v", skipped)
+	}
+}
+
+var (
+	_ Loader = LoadUniversal
+	_ Loader = LoadEWT
+	_ Loader = Load
+)
+
+func TestLoad(t *testing.T) {
+	if _, err := LoadUniversal("testdata/does-not-exist.conllu"); err == nil {
+		t.Error("Expected an error loading a file that doesn't exist")
+	}
+
+	dir, err := ioutil.TempDir("", "treebank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "ewt.zip")
+	f, err := os.Create(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	for _, name := range []string{"a.conllu", "b.conllu"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(udv2Conllu)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	sentences, err := LoadEWT(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sentences) != 4 {
+		t.Errorf("Expected 4 sentences. Got %d instead", len(sentences))
+	}
+}
+
+func TestSentenceTag_EnhancedGraph(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(udv2Conllu))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := sentences[1]
+	g, err := s.EnhancedGraph(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty := lingua.NodeID{Word: 4, Empty: 1}
+	assert.Equal("makan", g.Node(empty).Value)
+	assert.Equal(lingua.VERB, g.Node(empty).POSTag)
+	assert.Equal("4.1:nsubj", g.Deps(lingua.WordNode(4)))
+	assert.Equal("2:conj", g.Deps(empty))
+	assert.Equal(2, g.AnnotatedSentence[4].HeadID(), "the basic tree is kept")
+
+	// juga -> makan (4.1) -> Budi
+	path := g.Path(lingua.WordNode(5), lingua.WordNode(4))
+	if assert.Equal(2, len(path)) {
+		assert.Equal(empty, path[0].Head)
+		assert.Equal("nsubj", path[1].Label)
+	}
+
+	// replace the elided verb by a relation between the words
+	g2 := lingua.NewEnhancedGraph(g.AnnotatedSentence)
+	for _, e := range g.Edges() {
+		if !e.Head.IsEmpty() && !e.Dependent.IsEmpty() {
+			assert.Nil(g2.AddEdge(e.Head, e.Dependent, e.Label))
+		}
+	}
+	assert.Nil(g2.AddEdge(lingua.WordNode(2), lingua.WordNode(4), "conj"))
+	assert.Nil(g2.AddEdge(lingua.WordNode(4), lingua.WordNode(5), "advmod"))
+	s.SetEnhanced(g2)
+	assert.Equal(0, len(s.EmptyNodes))
+
+	var buf bytes.Buffer
+	if err = WriteConllu(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal("4\tBudi\tBudi\tPROPN\tX--\t_\t2\tconj\t2:conj\t_", lines[5])
+	assert.Equal("5\tjuga\tjuga\tADV\tD--\t_\t4\tadvmod\t4:advmod\tSpaceAfter=No", lines[6])
+
+	buf.Reset()
+	if err = WriteEnhancedConllu(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	lines = strings.Split(buf.String(), "\n")
+	assert.Equal("4\tBudi\tBudi\tPROPN\t_\t_\t2\tconj\t4.1:nsubj\t_", lines[5])
+	assert.Equal("4.1\tmakan\tmakan\tVERB\t_\t_\t_\t_\t2:conj\t_", lines[6])
+
+	read, err := ReadConllu(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(1, len(read)) && assert.Equal(1, len(read[0].EmptyNodes)) {
+		assert.Equal("4.1", read[0].EmptyNodes[0].ID())
+	}
+}
+
+const pennConllu = `# sent_id = ewt-1
+1	President	President	PROPN	NNP	Number=Sing	2	nn	_	_
+2	Bush	Bush	PROPN	NNP	Number=Sing	3	nsubj	_	_
+3	nominated	nominate	VERB	VBD	Mood=Ind|Tense=Past|VerbForm=Fin	0	root	_	_
+4	two	two	NUM	CD	NumType=Card	5	num	_	_
+5	individuals	individual	NOUN	NNS	Number=Plur	3	dobj	_	SpaceAfter=No
+6	.	.	PUNCT	.	_	3	punct	_	_
+
+`
+
+func TestReadConllu_TagSets(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(pennConllu), WithTagSet(lingua.PennTags), WithRelationSet(lingua.StanfordRelations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sentences[0]
+	assert.Equal([]lingua.POSTag{lingua.PROPN, lingua.PROPN, lingua.VERB, lingua.NUM, lingua.NOUN, lingua.PUNCT}, s.Tags)
+	assert.Equal([]lingua.DependencyType{lingua.Compound, lingua.NSubj, lingua.Root, lingua.NumMod, lingua.DObj, lingua.Punct}, s.Labels)
+	assert.Equal(lingua.Punctuation, s.Sentence[5].LexemeType)
+
+	tags := s.SetTags()
+	assert.Equal("NNS", tags[4].String())
+	assert.Equal(lingua.PennTags, tags[4].TagSet())
+	assert.Equal("nn", s.Relations()[0].String())
+
+	var buf bytes.Buffer
+	if err = WriteConllu(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(pennConllu, buf.String())
+
+	// the XPOS column is written from the Penn tags
+	s.Tags[0] = lingua.NOUN
+	s.Labels[3] = lingua.AMod
+	assert.Equal("NN", s.SetTags()[0].String())
+	buf.Reset()
+	if err = WriteConllu(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal("1\tPresident\tPresident\tPROPN\tNN\tNumber=Sing\t2\tnn\t_\t_", lines[1])
+	assert.Equal("4\ttwo\ttwo\tNUM\tCD\tNumType=Card\t5\tamod\t_\t_", lines[4])
+
+	// the same file, read with the default inventories
+	sentences, err = ReadConllu(strings.NewReader(pennConllu))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(lingua.PROPN, sentences[0].Tags[0])
+	assert.Equal(lingua.NoDepType, sentences[0].Labels[0], "nn is not a universal relation")
+	assert.Equal(lingua.UniversalTags, sentences[0].SetTags()[0].TagSet())
+}
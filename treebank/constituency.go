@@ -0,0 +1,292 @@
+package treebank
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+)
+
+// Labeler labels the dependency of the dependent child of a constituent on its head child. hf is the HeadFinder of the conversion.
+type Labeler func(hf HeadFinder, t *Tree, head, dependent int) lingua.DependencyType
+
+type treeConverter struct {
+	finder  HeadFinder
+	labeler Labeler
+	tagSet  *lingua.TagSet
+}
+
+type treeOpt func(*treeConverter)
+
+// WithHeadFinder sets the HeadFinder of the conversion. The default is CollinsHeadRules.
+func WithHeadFinder(hf HeadFinder) treeOpt {
+	return func(c *treeConverter) {
+		c.finder = hf
+	}
+}
+
+// WithLabeler sets the Labeler of the conversion. The default is DefaultLabeler.
+func WithLabeler(l Labeler) treeOpt {
+	return func(c *treeConverter) {
+		c.labeler = l
+	}
+}
+
+// WithTreeTagSet sets the TagSet of the tags of the trees. The default is lingua.PennTags.
+func WithTreeTagSet(ts *lingua.TagSet) treeOpt {
+	return func(c *treeConverter) {
+		c.tagSet = ts
+	}
+}
+
+// ConvertTree converts a constituency tree to dependencies: every word depends on the head word of the smallest constituent it doesn't head.
+// The tags are kept in the XPOS, and their POSTags are written in the UPOS (unless the TagSet is lingua.UniversalTags).
+// The empty elements (-NONE-) of the tree are removed first.
+//
+// These are the options:
+//		WithHeadFinder (default: CollinsHeadRules)
+//		WithLabeler (default: DefaultLabeler)
+//		WithTreeTagSet (default: lingua.PennTags)
+func ConvertTree(t *Tree, opts ...treeOpt) (SentenceTag, error) {
+	c := &treeConverter{
+		finder:  CollinsHeadRules,
+		labeler: DefaultLabeler,
+		tagSet:  lingua.PennTags,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	st := SentenceTag{TagSet: c.tagSet}
+	if t = t.WithoutEmpty(); t == nil {
+		return st, errors.New("The tree has no words")
+	}
+	root, err := c.convert(t, &st)
+	if err != nil {
+		return st, err
+	}
+	st.Heads[root] = 0
+	st.Labels[root] = lingua.Root
+	return st, nil
+}
+
+// ConvertTrees converts the trees to dependencies. See ConvertTree.
+func ConvertTrees(trees []*Tree, opts ...treeOpt) ([]SentenceTag, error) {
+	sentences := make([]SentenceTag, 0, len(trees))
+	for i, t := range trees {
+		st, err := ConvertTree(t, opts...)
+		if err != nil {
+			return sentences, errors.Wrapf(err, "Tree %d", i+1)
+		}
+		for j := range st.Sentence {
+			st.Sentence[j].Line = i
+		}
+		sentences = append(sentences, st)
+	}
+	return sentences, nil
+}
+
+// convert adds the words of the tree to the SentenceTag, and returns the index of the head word of the tree
+func (c *treeConverter) convert(t *Tree, st *SentenceTag) (int, error) {
+	if t.IsPreterminal() {
+		i := len(st.Sentence)
+		tag, _ := stringToPOSTag(c.tagSet, t.Label)
+		st.Sentence = append(st.Sentence, lingua.Lexeme{
+			Value:      lingua.UnescapeSpecials(t.Children[0].Label),
+			LexemeType: lexTypeOf(tag),
+			Col:        i,
+			SpaceAfter: " ",
+		})
+		st.Tags = append(st.Tags, tag)
+		if tagColumn(c.tagSet) == 3 {
+			st.UPOS = append(st.UPOS, t.Label)
+		} else {
+			st.UPOS = append(st.UPOS, posTagToString(lingua.UniversalTags, tag))
+			st.XPOS = append(st.XPOS, t.Label)
+		}
+		st.Heads = append(st.Heads, 0)
+		st.Labels = append(st.Labels, lingua.NoDepType)
+		return i, nil
+	}
+
+	heads := make([]int, len(t.Children))
+	for i, child := range t.Children {
+		if child.IsLeaf() {
+			return 0, errors.Errorf("%q in %v has no tag", child.Label, t.Label)
+		}
+		var err error
+		if heads[i], err = c.convert(child, st); err != nil {
+			return 0, err
+		}
+	}
+
+	h := c.finder.Head(t)
+	for i, w := range heads {
+		if i == h {
+			continue
+		}
+		st.Heads[w] = heads[h] + 1
+		st.Labels[w] = c.labeler(c.finder, t, h, i)
+	}
+	return heads[h], nil
+}
+
+// headOf returns the preterminal of the head word of the tree
+func headOf(hf HeadFinder, t *Tree) *Tree {
+	for !t.IsPreterminal() {
+		t = t.Children[hf.Head(t)]
+	}
+	return t
+}
+
+var (
+	punctTags  = map[string]bool{",": true, ".": true, ":": true, "``": true, "''": true, "-LRB-": true, "-RRB-": true, "HYPH": true, "NFP": true}
+	verbTags   = map[string]bool{"MD": true, "VB": true, "VBD": true, "VBG": true, "VBN": true, "VBP": true, "VBZ": true}
+	clauseCats = map[string]bool{"S": true, "SQ": true, "SINV": true}
+	passiveAux = map[string]bool{"be": true, "is": true, "are": true, "was": true, "were": true, "been": true, "being": true, "am": true, "'s": true, "'re": true,
+		"get": true, "gets": true, "got": true, "gotten": true, "getting": true}
+
+	// the constituents headed by predicates, whose nominal dependents are obl rather than nmod
+	predicateCats = map[string]bool{"VP": true, "S": true, "SQ": true, "SINV": true, "ADJP": true, "ADVP": true}
+)
+
+// DefaultLabeler labels the dependencies by the categories of the constituents, with the relations of UD v2 (e.g. obj, obl and aux:pass).
+// It works with function heads (CollinsHeadRules) and with content heads (ContentHeadRules). With function heads, a PP is headed by its preposition,
+// which is Case, and the object of the preposition is NMod. Dependencies it can't tell are Dep. See lingua.ToV1 for UD v1 relations.
+func DefaultLabeler(hf HeadFinder, t *Tree, head, dependent int) lingua.DependencyType {
+	p := t.Category()
+	h, d := t.Children[head], t.Children[dependent]
+	hc, dc := h.Category(), d.Category()
+	hw, dw := headOf(hf, h), headOf(hf, d)
+	before := dependent < head
+
+	switch {
+	case d.IsPreterminal() && punctTags[dc]:
+		return lingua.Punct
+	case dc == "CC" || dc == "CONJP":
+		return lingua.Coordination
+	case !before && coordinated(t, head):
+		return lingua.Conj
+	case d.HasFunctionTag("SBJ") || (before && dc == "NP" && clauseCats[p]):
+		if dc == "S" || dc == "SBAR" {
+			return lingua.CSubj
+		}
+		return lingua.NSubj
+	case dc == "NP" && d.HasFunctionTag("TMP"):
+		if predicateCats[p] {
+			return lingua.Obl_TMod
+		}
+		return lingua.NMod_TMod
+	case dc == "NP" && d.HasFunctionTag("ADV"):
+		if predicateCats[p] {
+			return lingua.Obl_NPMod
+		}
+		return lingua.NMod_NPMod
+	case dc == "NP" && d.Children[len(d.Children)-1].Category() == "POS":
+		return lingua.NMod_Poss
+	case verbTags[dc] && p == "VP" && hc == "VP":
+		if hw.Label == "VBN" && passiveAux[strings.ToLower(dw.Children[0].Label)] {
+			return lingua.Aux_Pass
+		}
+		return lingua.Aux
+	}
+
+	switch dc {
+	case "DT", "WDT":
+		return lingua.Det
+	case "PDT":
+		return lingua.Det_PreDet
+	case "PRP$", "WP$":
+		return lingua.NMod_Poss
+	case "POS":
+		return lingua.Case
+	case "RP", "PRT":
+		return lingua.Compound_Part
+	case "RB", "RBR", "RBS", "WRB", "ADVP", "WHADVP":
+		return lingua.AdvMod // negations too, as UD v2 has no neg
+	case "CD", "QP":
+		if p == "NP" {
+			return lingua.NumMod
+		}
+	case "JJ", "JJR", "JJS", "ADJP", "VBN", "VBG":
+		if p == "NP" {
+			return lingua.AMod
+		}
+	case "NN", "NNS", "NNP", "NNPS":
+		if p == "NP" && before {
+			return lingua.Compound
+		}
+	case "IN", "TO":
+		switch p {
+		case "PP", "WHPP":
+			return lingua.Case
+		case "SBAR", "VP":
+			return lingua.Mark
+		}
+	case "PP", "WHPP":
+		if dw.Label == "IN" || dw.Label == "TO" {
+			return lingua.Case // prep
+		}
+		if predicateCats[p] {
+			return lingua.Obl
+		}
+		return lingua.NMod
+	case "NP", "WHNP":
+		switch p {
+		case "VP":
+			for _, c := range t.Children[dependent+1:] {
+				if c.Category() == "NP" {
+					return lingua.IObj
+				}
+			}
+			return lingua.Obj
+		case "PP", "WHPP":
+			return lingua.NMod // pobj
+		case "NP":
+			if !before && dependent > head+1 && t.Children[head+1].Category() == "," {
+				return lingua.Appos
+			}
+		}
+	case "S", "SQ":
+		switch p {
+		case "VP", "ADJP":
+			for _, c := range d.Children {
+				if c.HasFunctionTag("SBJ") || c.Category() == "NP" {
+					return lingua.CComp
+				}
+			}
+			return lingua.XComp
+		case "PP", "SBAR":
+			return lingua.CComp // pcomp
+		case "S":
+			return lingua.Parataxis
+		}
+	case "SBAR":
+		switch p {
+		case "VP", "ADJP", "PP":
+			return lingua.CComp
+		case "NP":
+			if strings.HasPrefix(d.Children[0].Category(), "WH") {
+				return lingua.ACl_RelCl
+			}
+			return lingua.ACl
+		case "S", "SINV":
+			return lingua.AdvCl
+		}
+	case "VP":
+		if p == "NP" {
+			return lingua.ACl
+		}
+	}
+	return lingua.Dep
+}
+
+// coordinated checks if the head of the constituent is followed by a coordinating conjunction
+func coordinated(t *Tree, head int) bool {
+	for _, c := range t.Children[head+1:] {
+		if cat := c.Category(); cat == "CC" || cat == "CONJP" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package treebank
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+)
+
+// ConvertTags converts the tags of the sentence to the TagSet m.To, in place. The converted tags are written in the column of m.To
+// (the UPOS for lingua.UniversalTags, the XPOS for any other TagSet), and the other tag column is kept.
+//
+// It returns the positions of the words whose tags lost information in the conversion. See lingua.TagMap.Lossy.
+func (s *SentenceTag) ConvertTags(m *lingua.TagMap) (lossy []int) {
+	tags, lossy := m.Convert(s.SetTags())
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		s.Tags[i] = t.POSTag()
+		names[i] = t.String()
+		if strings.HasPrefix(names[i], "-") {
+			names[i] = posTagToString(m.To, s.Tags[i])
+		}
+	}
+
+	s.TagSet = m.To
+	if tagColumn(m.To) == 3 {
+		s.UPOS = names
+	} else {
+		s.XPOS = names
+	}
+	return lossy
+}
+
+// ConvertTreebank reads a CONLLU treebank with the tags of m.From, and writes it to w with the tags of m.To. The file may be gzipped or zipped (see OpenSentenceTagReader).
+// Everything other than the converted tag column is written as it was read.
+//
+// It returns the number of words whose tags lost information in the conversion.
+func ConvertTreebank(w io.Writer, filename string, m *lingua.TagMap, opts ...conlluOpt) (int, error) {
+	r, err := OpenSentenceTagReader(context.Background(), filename, append(opts[:len(opts):len(opts)], WithTagSet(m.From))...)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	var lossy int
+	for {
+		st, err := r.Next()
+		if err == io.EOF {
+			return lossy, nil
+		}
+		if err != nil {
+			return lossy, errors.Wrapf(err, "Unable to convert %v", filename)
+		}
+
+		lossy += len(st.ConvertTags(m))
+		if err = WriteConllu(w, st); err != nil {
+			return lossy, err
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package treebank
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSentenceTag_ConvertTags(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(pennConllu), WithTagSet(lingua.PennTags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sentences[0]
+
+	lossy := s.ConvertTags(lingua.PennToPetrov)
+	assert.Equal([]int{0, 1, 2, 4, 5}, lossy, "CD is the only NUM")
+	assert.Equal(lingua.PetrovTags, s.TagSet)
+	assert.Equal([]lingua.POSTag{lingua.NOUN, lingua.NOUN, lingua.VERB, lingua.NUM, lingua.NOUN, lingua.PUNCT}, s.Tags)
+	assert.Equal([]string{"NOUN", "NOUN", "VERB", "NUM", "NOUN", "."}, s.XPOS)
+
+	var buf bytes.Buffer
+	if err = WriteConllu(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal("1\tPresident\tPresident\tPROPN\tNOUN\tNumber=Sing\t2\tnn\t_\t_", lines[1])
+	assert.Equal("6\t.\t.\tPUNCT\t.\t_\t3\tpunct\t_\t_", lines[6])
+
+	// universal tags are written in the UPOS column
+	m, err := lingua.NewTagMap(lingua.PetrovTags, lingua.UniversalTags, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ConvertTags(m)
+	assert.Equal(lingua.UniversalTags, s.SetTags()[0].TagSet())
+	assert.Equal("NOUN", s.UPOS[0])
+}
+
+func TestConvertTreebank(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "treebank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "ud.conllu")
+	if err = ioutil.WriteFile(filename, []byte(pennConllu), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	lossy, err := ConvertTreebank(&buf, filename, lingua.UniversalToPenn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(0, lossy)
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal("# sent_id = ewt-1", lines[0])
+	assert.Equal("1\tPresident\tPresident\tPROPN\tNNP\tNumber=Sing\t2\tnn\t_\t_", lines[1])
+	assert.Equal("5\tindividuals\tindividual\tNOUN\tNN\tNumber=Plur\t3\tdobj\t_\tSpaceAfter=No", lines[5])
+
+	_, err = ConvertTreebank(&buf, filepath.Join(dir, "missing.conllu"), lingua.UniversalToPenn)
+	assert.Error(err)
+}
+
+func TestConvertTags_CCONJ(t *testing.T) {
+	assert := assert.New(t)
+	conllu := "1\tAni\tAni\tPROPN\tNNP\t_\t0\troot\t_\t_\n2\tdan\tdan\tCCONJ\tCC\t_\t3\tcc\t_\t_\n3\tBudi\tBudi\tPROPN\tNNP\t_\t1\tconj\t_\t_\n"
+	sentences, err := ReadConllu(strings.NewReader(conllu))
+	if !assert.NoError(err) {
+		return
+	}
+	st := sentences[0]
+	assert.Equal(lingua.CONJ, st.Tags[1], "CCONJ isn't read as X")
+
+	var buf bytes.Buffer
+	assert.NoError(WriteConllu(&buf, st))
+	assert.Equal(conllu+"\n", buf.String())
+
+	assert.Empty(st.ConvertTags(lingua.UniversalToPenn))
+	assert.Equal("CC", st.XPOS[1])
+	assert.NotContains(st.ConvertTags(lingua.PennToUniversal), 1, "CC is the only CCONJ")
+	assert.Equal("CCONJ", st.UPOS[1])
+}
@@ -0,0 +1,191 @@
+package treebank
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+)
+
+// Format is a treebank format that Load can read.
+type Format struct {
+	Name       string
+	Extensions []string               // the extensions of the files of the format, e.g. ".conllu"
+	Sniff      func(head []byte) bool // checks if the start of a file is in the format. It may be nil
+	Read       func(r io.Reader, opts ...conlluOpt) ([]SentenceTag, error)
+
+	columns []conlluOpt // the options of the tabular formats, which are read one sentence at a time. nil for the other formats
+}
+
+var (
+	formatsLock sync.RWMutex
+	formats     []Format
+)
+
+// sniffSize is the number of bytes a Format sniffs
+const sniffSize = 4096
+
+func init() {
+	// the formats registered last are tried first, so CONLLU is tried before CoNLL-X
+	RegisterFormat(columnsFormat(Format{Name: "tsv", Extensions: []string{".tsv"}, Sniff: sniffColumns(2, 2)}, TSVColumns))
+	RegisterFormat(columnsFormat(Format{Name: "conll09", Extensions: []string{".conll09", ".conll2009"}, Sniff: sniffColumns(12, -1)}, Conll09Columns))
+	RegisterFormat(columnsFormat(Format{Name: "conllx", Extensions: []string{".conll", ".conllx"}, Sniff: sniffColumns(10, 10)}, ConllXColumns))
+	// CONLLU files keep the columns set WithColumns
+	RegisterFormat(Format{Name: "conllu", Extensions: []string{".conllu"}, Sniff: sniffConllu, Read: ReadConllu, columns: []conlluOpt{}})
+	RegisterFormat(Format{Name: "ptb", Extensions: []string{".mrg", ".ptb"}, Sniff: sniffPTB, Read: readPTB})
+}
+
+// RegisterFormat registers a Format for Load. A Format with the same name as a registered Format replaces it.
+// The Formats registered last are tried first.
+func RegisterFormat(f Format) {
+	formatsLock.Lock()
+	defer formatsLock.Unlock()
+	for i, g := range formats {
+		if g.Name == f.Name {
+			formats = append(formats[:i], formats[i+1:]...)
+			break
+		}
+	}
+	formats = append([]Format{f}, formats...)
+}
+
+// FormatOf finds the Format of a file, by the extension of its name (without .gz), or else by sniffing the start of the file.
+func FormatOf(name string, head []byte) (Format, bool) {
+	formatsLock.RLock()
+	defer formatsLock.RUnlock()
+
+	ext := strings.ToLower(filepath.Ext(strings.TrimSuffix(name, ".gz")))
+	for _, f := range formats {
+		for _, e := range f.Extensions {
+			if e == ext {
+				return f, true
+			}
+		}
+	}
+	for _, f := range formats {
+		if f.Sniff != nil && f.Sniff(head) {
+			return f, true
+		}
+	}
+	return Format{}, false
+}
+
+// Load loads a treebank in any of the registered Formats: CONLLU, CoNLL-X, CoNLL-2009, TSV and PTB trees.
+// The file may be gzipped, or a zip, tar or tar.gz archive of treebank files. The Format of each file is found with FormatOf.
+//
+// Errors in the files of an archive are wrapped with the name of the file. Use OpenSentenceTagReader to read the sentences one at a time.
+func Load(filename string) ([]SentenceTag, error) {
+	return LoadWith(filename)
+}
+
+// LoadWith is Load with options. The options are passed to the Read of the Format (see WithColumns for the tabular formats).
+func LoadWith(filename string, opts ...conlluOpt) ([]SentenceTag, error) {
+	r, err := OpenSentenceTagReader(context.Background(), filename, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to load %v", filename)
+	}
+	defer r.Close()
+
+	sentences, err := readAll(r)
+	return sentences, errors.Wrapf(err, "Unable to load %v", filename)
+}
+
+// decoder reads the file in the Format: one sentence at a time for the tabular formats, or the whole file at once with Read.
+func (f Format) decoder(r io.Reader, opts ...conlluOpt) decoder {
+	if f.columns != nil {
+		return newConlluReader(r, append(opts[:len(opts):len(opts)], f.columns...)...)
+	}
+	s, err := f.Read(r, opts...)
+	return &sentences{s: s, err: err}
+}
+
+// columnsFormat makes a Format of the columns
+func columnsFormat(f Format, c Columns) Format {
+	f.columns = []conlluOpt{WithColumns(c)}
+	f.Read = func(r io.Reader, opts ...conlluOpt) ([]SentenceTag, error) {
+		return ReadConllu(r, append(opts[:len(opts):len(opts)], f.columns...)...)
+	}
+	return f
+}
+
+// readPTB reads trees, and converts them with the TagSet of the options
+func readPTB(r io.Reader, opts ...conlluOpt) ([]SentenceTag, error) {
+	ts := newConlluReader(r, opts...).tagSet
+	if ts == lingua.UniversalTags {
+		ts = lingua.PennTags
+	}
+	trees, err := ReadPTB(r)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertTrees(trees, WithTreeTagSet(ts))
+}
+
+// sniffLines returns the columns of the lines at the start of a file, without the comments and the last line, which may be cut short.
+func sniffLines(head []byte) [][]string {
+	lines := strings.Split(string(head), "\n")
+	if len(head) == sniffSize && len(lines) > 1 {
+		lines = lines[:len(lines)-1]
+	}
+	var retVal [][]string
+	for _, l := range lines {
+		l = strings.TrimRight(l, "\r")
+		if strings.TrimSpace(l) == "" || strings.HasPrefix(l, "#") {
+			continue
+		}
+		retVal = append(retVal, strings.Split(l, "\t"))
+	}
+	return retVal
+}
+
+// sniffColumns checks that the lines have between min and max (-1 for no maximum) columns. Files with more than 2 columns have to start with an ID.
+func sniffColumns(min, max int) func([]byte) bool {
+	return func(head []byte) bool {
+		lines := sniffLines(head)
+		for _, cols := range lines {
+			if len(cols) < min || (max >= 0 && len(cols) > max) {
+				return false
+			}
+			if _, err := strconv.Atoi(cols[0]); min > 2 && err != nil {
+				return false
+			}
+		}
+		return len(lines) > 0
+	}
+}
+
+// sniffConllu checks for the comments, multiword tokens, empty nodes and the MISC and DEPS of a CONLLU file, or for universal tags
+func sniffConllu(head []byte) bool {
+	lines := sniffLines(head)
+	for _, cols := range lines {
+		if len(cols) != 10 {
+			return false
+		}
+	}
+	if len(lines) == 0 {
+		return false
+	}
+	if bytes.HasPrefix(head, []byte("#")) || bytes.Contains(head, []byte("\n#")) {
+		return true
+	}
+	universal := true
+	for _, cols := range lines {
+		if strings.ContainsAny(cols[0], "-.") || strings.Contains(cols[8], ":") || strings.Contains(cols[9], "=") {
+			return true
+		}
+		if _, ok := lingua.UniversalTags.Tag(cols[3]); !ok {
+			universal = false
+		}
+	}
+	return universal
+}
+
+func sniffPTB(head []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(head), []byte("("))
+}
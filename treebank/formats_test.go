@@ -0,0 +1,258 @@
+package treebank
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+const conllxSample = `1	President	President	N	NNP	_	2	nn	2	nn
+2	Bush	Bush	N	NNP	sg|nom	3	nsubj	3	nsubj
+3	nominated	nominate	V	VBD	_	0	root	0	root
+4	individuals	individual	N	NNS	_	3	dobj	1	dep
+
+`
+
+const conll09Sample = `1	President	president	president	NNP	NNP	_	_	2	2	NAME	NAME	_	_	_
+2	Bush	bush	bush	NNP	NNP	_	_	3	3	SBJ	SBJ	_	_	A0
+3	nominated	nominate	nominate	VBD	VBN	_	_	0	0	ROOT	ROOT	Y	nominate.01	_
+4	individuals	individual	individuals	NNS	NNS	_	_	3	2	OBJ	OBJ	_	_	A1
+
+`
+
+const tsvSample = `President	NNP
+Bush	NNP
+nominated	VBD
+
+Individuals	NNS
+.	.
+`
+
+func TestReadConllu_Columns(t *testing.T) {
+	assert := assert.New(t)
+
+	sentences, err := ReadConllu(strings.NewReader(conllxSample), WithColumns(ConllXColumns), WithTagSet(lingua.PennTags), WithRelationSet(lingua.StanfordRelations))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := sentences[0]
+	assert.Equal([]int{2, 3, 0, 3}, s.Heads)
+	assert.Equal([]lingua.DependencyType{lingua.Compound, lingua.NSubj, lingua.Root, lingua.DObj}, s.Labels)
+	assert.Equal([]string{"N", "N", "V", "N"}, s.UPOS)
+	assert.Equal("NNS", s.SetTags()[3].String())
+	assert.Nil(s.Features[1], "sg|nom are not CONLLU features")
+
+	sentences, err = ReadConllu(strings.NewReader(conllxSample), WithColumns(ConllXColumns), PredictedColumns())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal([]int{2, 3, 0, 1}, sentences[0].Heads)
+
+	sentences, err = ReadConllu(strings.NewReader(conll09Sample), WithColumns(Conll09Columns), WithTagSet(lingua.PennTags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = sentences[0]
+	assert.Equal([]int{2, 3, 0, 3}, s.Heads)
+	assert.Equal([]string{"president", "bush", "nominate", "individual"}, s.Lemmas)
+	assert.Equal([]string{"NNP", "NNP", "VBD", "NNS"}, s.XPOS)
+	assert.Equal([]string{"", "", "", ""}, s.UPOS)
+
+	sentences, err = ReadConllu(strings.NewReader(conll09Sample), WithColumns(Conll09Columns), WithTagSet(lingua.PennTags), PredictedColumns())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal([]int{2, 3, 0, 2}, sentences[0].Heads)
+	assert.Equal("individuals", sentences[0].Lemmas[3])
+	assert.Equal("VBN", sentences[0].XPOS[2])
+
+	sentences, err = ReadConllu(strings.NewReader(tsvSample), WithColumns(TSVColumns), WithTagSet(lingua.PennTags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(sentences))
+	assert.Equal([]int{-1, -1, -1}, sentences[0].Heads)
+	assert.Equal([]lingua.POSTag{lingua.NOUN, lingua.PUNCT}, sentences[1].Tags)
+	assert.False(sentences[0].HasTree())
+	assert.Empty(Validate(sentences))
+	stats := ComputeStats(sentences)
+	assert.Equal(2, stats.NoTree)
+	assert.Equal(0, stats.InvalidTrees)
+	assert.Equal(1, stats.Tags["NNS"])
+
+	// the SentenceTags are written as CONLLU
+	var buf bytes.Buffer
+	if err = WriteConllu(&buf, sentences[1]); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("1\tIndividuals\t_\t_\tNNS\t_\t_\t_\t_\t_\n2\t.\t_\t_\t.\t_\t_\t_\t_\t_\n\n", buf.String())
+
+	for c, s := range map[Columns]string{
+		ConllXColumns:  "1\tword\t_\tN\n",
+		Conll09Columns: "1\tword\tword\tword\tN\tN\t_\t_\t0\t0\n",
+		TSVColumns:     "word\tN\tfoo\n",
+	} {
+		_, err = ReadConllu(strings.NewReader(s), WithColumns(c))
+		_, ok := err.(*ParseError)
+		assert.True(ok, s)
+	}
+}
+
+func TestFormatOf(t *testing.T) {
+	assert := assert.New(t)
+	for name, head := range map[string]string{
+		"conllu":  udv2Conllu,
+		"conllx":  conllxSample,
+		"conll09": conll09Sample,
+		"tsv":     tsvSample,
+		"ptb":     samplePTB,
+	} {
+		f, ok := FormatOf("data", []byte(head))
+		assert.True(ok, name)
+		assert.Equal(name, f.Name)
+	}
+	assert.Equal("conllu", sniffedName("x.txt", sampleConllu))
+
+	for name, format := range map[string]string{
+		"en.conllu.gz":   "conllu",
+		"wsj_0001.mrg":   "ptb",
+		"en.conll2009":   "conll09",
+		"tags.TSV":       "tsv",
+		"dev.conll":      "conllx",
+		"other.conll.gz": "conllx",
+	} {
+		assert.Equal(format, sniffedName(name, udv2Conllu), name)
+	}
+
+	_, ok := FormatOf("data.txt", []byte("just some text"))
+	assert.False(ok)
+}
+
+func sniffedName(name, head string) string {
+	f, _ := FormatOf(name, []byte(head))
+	return f.Name
+}
+
+func TestLoad_Archives(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "treebank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a tar.gz of all the formats, with and without extensions
+	filename := filepath.Join(dir, "treebanks.tar.gz")
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for _, f := range []struct{ name, content string }{
+		{"ud/a.conllu", udv2Conllu},
+		{"ud/b", conllxSample},
+		{"c.conll09", conll09Sample},
+		{"d.tsv", tsvSample},
+		{"wsj/wsj_0001.mrg", samplePTB},
+	} {
+		if err = tw.WriteHeader(&tar.Header{Name: f.name, Mode: 0644, Size: int64(len(f.content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = io.WriteString(tw, f.content); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = ioutil.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sentences, err := LoadWith(filename, WithTagSet(lingua.PennTags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2+1+1+2+2, len(sentences))
+	assert.Equal("The cat will eat the fish in the kitchen .", sentences[6].String())
+
+	// the SentenceTagReader reads the same sentences one at a time
+	r, err := OpenSentenceTagReader(context.Background(), filename, WithTagSet(lingua.PennTags))
+	if err != nil {
+		t.Fatal(err)
+	}
+	streamed, err := readAll(r)
+	assert.NoError(err)
+	assert.Equal(sentences, streamed)
+	assert.NoError(r.Close())
+
+	// a gzipped file
+	filename = filepath.Join(dir, "tags.tsv.gz")
+	buf.Reset()
+	gz = gzip.NewWriter(&buf)
+	io.WriteString(gz, tsvSample)
+	gz.Close()
+	if err = ioutil.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sentences, err = Load(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(sentences))
+
+	// errors in an archive have the name of the file
+	filename = filepath.Join(dir, "bad.tar")
+	buf.Reset()
+	tw = tar.NewWriter(&buf)
+	tw.WriteHeader(&tar.Header{Name: "bad.conllu", Mode: 0644, Size: 6, Typeflag: tar.TypeReg})
+	io.WriteString(tw, "1\tx\t_\n")
+	tw.Close()
+	if err = ioutil.WriteFile(filename, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	_, err = Load(filename)
+	assert.Error(err)
+	assert.Contains(err.Error(), "bad.conllu in "+filename)
+	_, ok := errors.Cause(err).(*ParseError)
+	assert.True(ok)
+}
+
+func TestRegisterFormat(t *testing.T) {
+	assert := assert.New(t)
+	RegisterFormat(Format{
+		Name:       "words",
+		Extensions: []string{".words"},
+		Read: func(r io.Reader, opts ...conlluOpt) ([]SentenceTag, error) {
+			b, err := ioutil.ReadAll(r)
+			var st SentenceTag
+			for _, w := range strings.Fields(string(b)) {
+				st.Sentence = append(st.Sentence, lingua.Lexeme{Value: w})
+			}
+			return []SentenceTag{st}, err
+		},
+	})
+	defer func() {
+		formatsLock.Lock()
+		formats = formats[1:]
+		formatsLock.Unlock()
+	}()
+
+	f, ok := FormatOf("a.words", nil)
+	assert.True(ok)
+	sentences, err := f.Read(strings.NewReader("a b c"))
+	assert.NoError(err)
+	assert.Equal("a b c", sentences[0].String())
+}
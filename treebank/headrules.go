@@ -0,0 +1,167 @@
+package treebank
+
+// HeadFinder finds the head of a constituent: the child that the other children depend on.
+type HeadFinder interface {
+	// Head returns the index of the head child of the constituent. The constituent is never a preterminal.
+	Head(t *Tree) int
+}
+
+// HeadDirection is the direction the children of a constituent are searched in by a HeadRule
+type HeadDirection byte
+
+const (
+	// HeadLeft searches for each category in turn, from the leftmost child
+	HeadLeft HeadDirection = iota
+	// HeadRight searches for each category in turn, from the rightmost child
+	HeadRight
+	// HeadLeftDis searches for the leftmost child of any of the categories
+	HeadLeftDis
+	// HeadRightDis searches for the rightmost child of any of the categories
+	HeadRightDis
+)
+
+// HeadRule is a row of a head table: the children of a constituent are searched in the Direction for the Categories.
+// A rule without Categories is the first child in the Direction.
+type HeadRule struct {
+	Direction  HeadDirection
+	Categories []string
+}
+
+// HeadRules is a head table in the Collins/Magerman style: the rules of each category are tried in order, until one of them finds a child.
+// If none does, the head is the first child in the Direction of the first rule. Categories without rules are headed by their first child.
+//
+// A head that is preceded by a coordinating conjunction (CC or CONJP) is moved to the conjunct before the conjunction,
+// so that the first conjunct heads a coordination.
+type HeadRules map[string][]HeadRule
+
+// Head returns the head child of the constituent
+func (hr HeadRules) Head(t *Tree) int {
+	n := len(t.Children)
+	if n == 1 {
+		return 0
+	}
+
+	rules := hr[t.Category()]
+	h := -1
+	for _, rule := range rules {
+		if h = rule.find(t.Children); h >= 0 {
+			break
+		}
+	}
+	if h < 0 {
+		h = 0
+		if len(rules) > 0 && (rules[0].Direction == HeadRight || rules[0].Direction == HeadRightDis) {
+			h = n - 1
+		}
+	}
+
+	if h >= 2 {
+		if cat := t.Children[h-1].Category(); cat == "CC" || cat == "CONJP" {
+			h -= 2
+		}
+	}
+	return h
+}
+
+func (r HeadRule) find(children []*Tree) int {
+	n := len(children)
+	right := r.Direction == HeadRight || r.Direction == HeadRightDis
+	at := func(i int) int {
+		if right {
+			return n - 1 - i
+		}
+		return i
+	}
+
+	if len(r.Categories) == 0 {
+		return at(0)
+	}
+
+	switch r.Direction {
+	case HeadLeft, HeadRight:
+		for _, cat := range r.Categories {
+			for i := 0; i < n; i++ {
+				if children[at(i)].Category() == cat {
+					return at(i)
+				}
+			}
+		}
+	default:
+		for i := 0; i < n; i++ {
+			cat := children[at(i)].Category()
+			for _, c := range r.Categories {
+				if c == cat {
+					return at(i)
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// CollinsHeadRules is the head table of Collins (1999), as modified by Magerman (1995), for the Penn Treebank.
+var CollinsHeadRules = HeadRules{
+	"ADJP":   {{HeadLeft, []string{"NNS", "QP", "NN", "$", "ADVP", "JJ", "VBN", "VBG", "ADJP", "JJR", "NP", "JJS", "DT", "FW", "RBR", "RBS", "SBAR", "RB"}}},
+	"ADVP":   {{HeadRight, []string{"RB", "RBR", "RBS", "FW", "ADVP", "TO", "CD", "JJR", "JJ", "IN", "NP", "JJS", "NN"}}},
+	"CONJP":  {{HeadRight, []string{"CC", "RB", "IN"}}},
+	"FRAG":   {{HeadRight, nil}},
+	"INTJ":   {{HeadLeft, nil}},
+	"LST":    {{HeadRight, []string{"LS", ":"}}},
+	"NAC":    {{HeadLeft, []string{"NN", "NNS", "NNP", "NNPS", "NP", "NAC", "EX", "$", "CD", "QP", "PRP", "VBG", "JJ", "JJS", "JJR", "ADJP", "FW"}}},
+	"NX":     {{HeadLeft, nil}},
+	"PP":     {{HeadRight, []string{"IN", "TO", "VBG", "VBN", "RP", "FW"}}},
+	"PRN":    {{HeadLeft, nil}},
+	"PRT":    {{HeadRight, []string{"RP"}}},
+	"QP":     {{HeadLeft, []string{"$", "IN", "NNS", "NN", "JJ", "RB", "DT", "CD", "NCD", "QP", "JJR", "JJS"}}},
+	"RRC":    {{HeadRight, []string{"VP", "NP", "ADVP", "ADJP", "PP"}}},
+	"S":      {{HeadLeft, []string{"TO", "IN", "VP", "S", "SBAR", "ADJP", "UCP", "NP"}}},
+	"SBAR":   {{HeadLeft, []string{"WHNP", "WHPP", "WHADVP", "WHADJP", "IN", "DT", "S", "SQ", "SINV", "SBAR", "FRAG"}}},
+	"SBARQ":  {{HeadLeft, []string{"SQ", "S", "SINV", "SBARQ", "FRAG"}}},
+	"SINV":   {{HeadLeft, []string{"VBZ", "VBD", "VBP", "VB", "MD", "VP", "S", "SINV", "ADJP", "NP"}}},
+	"SQ":     {{HeadLeft, []string{"VBZ", "VBD", "VBP", "VB", "MD", "VP", "SQ"}}},
+	"UCP":    {{HeadRight, nil}},
+	"VP":     {{HeadLeft, []string{"TO", "VBD", "VBN", "MD", "VBZ", "VB", "VBG", "VBP", "VP", "ADJP", "NN", "NNS", "NP"}}},
+	"WHADJP": {{HeadLeft, []string{"CC", "WRB", "JJ", "ADJP"}}},
+	"WHADVP": {{HeadRight, []string{"CC", "WRB"}}},
+	"WHNP":   {{HeadLeft, []string{"WDT", "WP", "WP$", "WHADJP", "WHPP", "WHNP"}}},
+	"WHPP":   {{HeadRight, []string{"IN", "TO", "FW"}}},
+	"X":      {{HeadRight, nil}},
+	"NP": {
+		{HeadRightDis, []string{"NN", "NNP", "NNPS", "NNS", "NX", "POS", "JJR"}},
+		{HeadLeft, []string{"NP"}},
+		{HeadRightDis, []string{"$", "ADJP", "PRN"}},
+		{HeadRight, []string{"CD"}},
+		{HeadRightDis, []string{"JJ", "JJS", "RB", "QP"}},
+	},
+}
+
+// ContentHeadRules is CollinsHeadRules with content words as heads, closer to Universal Dependencies: the main verb heads its auxiliaries,
+// the object of a preposition heads the prepositional phrase, and a clause heads its complementizer.
+var ContentHeadRules = contentHeadRules()
+
+func contentHeadRules() HeadRules {
+	hr := make(HeadRules, len(CollinsHeadRules))
+	for cat, rules := range CollinsHeadRules {
+		hr[cat] = rules
+	}
+
+	hr["VP"] = []HeadRule{{HeadLeft, []string{"VP", "VBD", "VBN", "VBZ", "VB", "VBG", "VBP", "ADJP", "NN", "NNS", "NP", "MD", "TO"}}}
+	hr["PP"] = []HeadRule{
+		{HeadLeft, []string{"NP", "S", "SBAR", "ADJP", "ADVP", "PP"}},
+		{HeadRight, []string{"IN", "TO", "VBG", "VBN", "RP", "FW"}},
+	}
+	hr["WHPP"] = []HeadRule{
+		{HeadLeft, []string{"WHNP", "NP"}},
+		{HeadRight, []string{"IN", "TO", "FW"}},
+	}
+	hr["SBAR"] = []HeadRule{{HeadLeft, []string{"S", "SQ", "SINV", "SBAR", "FRAG", "WHNP", "WHPP", "WHADVP", "WHADJP", "IN", "DT"}}}
+	hr["S"] = []HeadRule{{HeadLeft, []string{"VP", "S", "SBAR", "ADJP", "UCP", "NP", "TO", "IN"}}}
+	hr["NP"] = []HeadRule{
+		{HeadRightDis, []string{"NN", "NNP", "NNPS", "NNS", "NX", "JJR"}},
+		{HeadLeft, []string{"NP"}},
+		{HeadRightDis, []string{"$", "ADJP", "PRN"}},
+		{HeadRight, []string{"CD"}},
+		{HeadRightDis, []string{"JJ", "JJS", "RB", "QP"}},
+	}
+	return hr
+}
@@ -0,0 +1,44 @@
+package treebank
+
+import (
+	"io"
+
+	"github.com/sapariduo/lingua"
+)
+
+// QueryMatch is a match of a lingua.Pattern in a sentence of a treebank
+type QueryMatch struct {
+	lingua.Match
+	Sentence    int // the index of the sentence in the treebank
+	SentenceTag SentenceTag
+}
+
+// Query returns the matches of the pattern in the sentences. The sentences are annotated with the AnnotationFixer, which may be nil.
+func Query(sentences []SentenceTag, p *lingua.Pattern, f lingua.AnnotationFixer) []QueryMatch {
+	var matches []QueryMatch
+	for i, st := range sentences {
+		for _, m := range p.Match(st.AnnotatedSentence(f)) {
+			matches = append(matches, QueryMatch{Match: m, Sentence: i, SentenceTag: st})
+		}
+	}
+	return matches
+}
+
+// QueryReader calls fn with each match of the pattern in the sentences read by r, so that large treebanks don't have to be loaded into memory.
+// The sentences are annotated with the AnnotationFixer, which may be nil. It stops at the first error of r or of fn.
+func QueryReader(r *SentenceTagReader, p *lingua.Pattern, f lingua.AnnotationFixer, fn func(QueryMatch) error) error {
+	for i := 0; ; i++ {
+		st, err := r.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, m := range p.Match(st.AnnotatedSentence(f)) {
+			if err = fn(QueryMatch{Match: m, Sentence: i, SentenceTag: st}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package treebank
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+const passiveConllu = `# sent_id = p1
+1	Buku	buku	NOUN	_	_	2	nsubj:pass	_	_
+2	dibaca	baca	VERB	_	Voice=Pass	0	root	_	_
+3	oleh	oleh	ADP	_	_	4	case	_	_
+4	Ani	Ani	PROPN	_	_	2	obl:agent	_	_
+
+# sent_id = p2
+1	Ani	Ani	PROPN	_	_	2	nsubj	_	_
+2	membaca	baca	VERB	_	Voice=Act	0	root	_	_
+3	buku	buku	NOUN	_	_	2	obj	_	_
+
+# sent_id = p3
+1	Surat	surat	NOUN	_	_	2	nsubj:pass	_	_
+2	ditulis	tulis	VERB	_	Voice=Pass	0	root	_	_
+3	oleh	oleh	ADP	_	_	4	case	_	_
+4	Budi	Budi	PROPN	_	_	2	obl:agent	_	_
+`
+
+func TestQuery(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(passiveConllu))
+	if !assert.NoError(err) {
+		return
+	}
+
+	p := lingua.MustCompilePattern("{tag:VERB;word:/^di/}=verb >obl:agent {}=agent")
+	matches := Query(sentences, p, nil)
+	if !assert.Len(matches, 2) {
+		return
+	}
+	assert.Equal(0, matches[0].Sentence)
+	assert.Equal("dibaca", matches[0].Node.Value)
+	assert.Equal("Ani", matches[0].Bindings["agent"].Value)
+	assert.Equal("p3", matches[1].SentenceTag.ID())
+	assert.Equal("tulis", matches[1].Bindings["verb"].Lemma)
+
+	r := NewSentenceTagReader(context.Background(), strings.NewReader(passiveConllu))
+	var streamed []int
+	err = QueryReader(r, lingua.MustCompilePattern("{lemma:baca}"), nil, func(m QueryMatch) error {
+		streamed = append(streamed, m.Sentence)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal([]int{0, 1}, streamed)
+
+	stop := errors.New("stop")
+	r = NewSentenceTagReader(context.Background(), strings.NewReader(passiveConllu))
+	err = QueryReader(r, p, nil, func(QueryMatch) error { return stop })
+	assert.Equal(stop, err)
+}
@@ -0,0 +1,319 @@
+package treebank
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// SentenceTagReader reads SentenceTags from treebank files one at a time, so that large treebanks don't have to be loaded into memory.
+//
+// Gzipped files are decompressed transparently. The files of a zip, tar or tar.gz archive are read one after another, and each file is only opened
+// when the previous one has been read. The reader stops with the error of the context once the context is done.
+type SentenceTagReader struct {
+	ctx  context.Context
+	opts []conlluOpt
+
+	sources []source // the files that are yet to be read
+	archive string   // the name of the zip archive that the files are in, if any
+	tars    []tarFile
+
+	r       decoder
+	name    string // the name of the file being read
+	current []io.Closer
+	closers []io.Closer // closed by Close
+}
+
+type source struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// tarFile is a tar archive being read, with the closers of the file it is in
+type tarFile struct {
+	r       *tar.Reader
+	name    string
+	closers []io.Closer
+}
+
+// decoder reads the sentences of a file one at a time. It returns io.EOF at the end of the file.
+type decoder interface {
+	next() (SentenceTag, error)
+}
+
+// sentences is the decoder of the Formats that read a whole file at once
+type sentences struct {
+	s   []SentenceTag
+	err error
+}
+
+func (d *sentences) next() (SentenceTag, error) {
+	if len(d.s) == 0 {
+		if d.err == nil {
+			return SentenceTag{}, io.EOF
+		}
+		err := d.err
+		d.err = nil
+		return SentenceTag{}, err
+	}
+	st := d.s[0]
+	d.s = d.s[1:]
+	return st, nil
+}
+
+// NewSentenceTagReader creates a *SentenceTagReader that reads a CONLLU file (or the columns set WithColumns), which may be gzipped.
+func NewSentenceTagReader(ctx context.Context, r io.Reader, opts ...conlluOpt) *SentenceTagReader {
+	return &SentenceTagReader{
+		ctx:  ctx,
+		opts: opts,
+		sources: []source{{
+			open: func() (io.ReadCloser, error) { return ioutil.NopCloser(r), nil },
+		}},
+	}
+}
+
+// OpenSentenceTagReader opens a treebank file in any of the registered Formats (see Load), which may be gzipped,
+// or a zip, tar or tar.gz archive of treebank files. The Format of each file is found with FormatOf.
+// The *SentenceTagReader has to be closed when it is no longer needed.
+func OpenSentenceTagReader(ctx context.Context, filename string, opts ...conlluOpt) (*SentenceTagReader, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	magic := make([]byte, len(zipMagic))
+	n, _ := io.ReadFull(f, magic)
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	r := &SentenceTagReader{
+		ctx:  ctx,
+		opts: opts,
+	}
+	if !bytes.Equal(magic[:n], zipMagic) {
+		r.sources = []source{{
+			name: filename,
+			open: func() (io.ReadCloser, error) { return ioutil.NopCloser(f), nil },
+		}}
+		r.closers = []io.Closer{f}
+		return r, nil
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	zr, err := zip.NewReader(f, info.Size())
+	if err != nil {
+		f.Close()
+		return nil, errors.Wrapf(err, "Unable to open %v", filename)
+	}
+	r.archive = filename
+	r.closers = []io.Closer{f}
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		r.sources = append(r.sources, source{name: file.Name, open: file.Open})
+	}
+	return r, nil
+}
+
+// Next returns the next SentenceTag. io.EOF is returned when there are no more sentences.
+//
+// Errors found in the files of an archive are wrapped with the name of the file. A *ParseError can be found with errors.Cause.
+func (r *SentenceTagReader) Next() (SentenceTag, error) {
+	for {
+		if err := r.ctx.Err(); err != nil {
+			return SentenceTag{}, err
+		}
+
+		if r.r == nil {
+			var err error
+			switch {
+			case len(r.tars) > 0:
+				err = r.nextEntry()
+			case len(r.sources) == 0:
+				return SentenceTag{}, io.EOF
+			default:
+				err = r.open()
+			}
+			if err != nil {
+				return SentenceTag{}, r.wrap(err)
+			}
+			continue
+		}
+
+		st, err := r.r.next()
+		switch {
+		case err == io.EOF:
+			r.r = nil
+			if err = r.closeCurrent(); err != nil {
+				return SentenceTag{}, r.wrap(err)
+			}
+		case err != nil:
+			return st, r.wrap(err)
+		default:
+			return st, nil
+		}
+	}
+}
+
+// Close closes the files opened by the reader.
+func (r *SentenceTagReader) Close() error {
+	err := r.closeCurrent()
+	for len(r.tars) > 0 {
+		if cerr := r.closeTar(); err == nil {
+			err = cerr
+		}
+	}
+	for _, c := range r.closers {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	r.closers = nil
+	r.sources = nil
+	r.r = nil
+	return err
+}
+
+// open opens the next source
+func (r *SentenceTagReader) open() error {
+	src := r.sources[0]
+	r.sources = r.sources[1:]
+	r.name = src.name
+
+	rc, err := src.open()
+	if err != nil {
+		return err
+	}
+	return r.decode(src.name, rc, []io.Closer{rc})
+}
+
+// nextEntry opens the next file of the innermost tar archive, or closes the archive after its last file
+func (r *SentenceTagReader) nextEntry() error {
+	t := r.tars[len(r.tars)-1]
+	for {
+		hdr, err := t.r.Next()
+		if err == io.EOF {
+			return r.closeTar()
+		}
+		if err != nil {
+			r.name = t.name
+			return errors.Wrapf(err, "Unable to read %v", t.name)
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			r.name = hdr.Name
+			return r.decode(hdr.Name, t.r, nil)
+		}
+	}
+}
+
+// decode decompresses a gzipped file, and starts reading the file in its Format, or the files of a tar archive.
+// The closers are closed once the file has been read. A file without a name is read as CONLLU.
+func (r *SentenceTagReader) decode(name string, rd io.Reader, closers []io.Closer) error {
+	br := bufio.NewReaderSize(rd, sniffSize)
+	if magic, _ := br.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			closeAll(closers)
+			return err
+		}
+		closers = append(closers, gz)
+		br = bufio.NewReaderSize(gz, sniffSize)
+		name = strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".tgz")
+	}
+
+	if isTar(br) {
+		r.tars = append(r.tars, tarFile{r: tar.NewReader(br), name: name, closers: closers})
+		return nil
+	}
+
+	r.current = closers
+	if name == "" {
+		r.r = newConlluReader(br, r.opts...)
+		return nil
+	}
+	head, _ := br.Peek(sniffSize)
+	f, ok := FormatOf(name, head)
+	if !ok {
+		r.closeCurrent()
+		return errors.Errorf("Unknown format of %v", name)
+	}
+	r.r = f.decoder(br, r.opts...)
+	return nil
+}
+
+func (r *SentenceTagReader) closeCurrent() error {
+	err := closeAll(r.current)
+	r.current = nil
+	return err
+}
+
+func (r *SentenceTagReader) closeTar() error {
+	t := r.tars[len(r.tars)-1]
+	r.tars = r.tars[:len(r.tars)-1]
+	return closeAll(t.closers)
+}
+
+// closeAll closes the closers in the reverse order
+func closeAll(closers []io.Closer) error {
+	var err error
+	for i := len(closers) - 1; i >= 0; i-- {
+		if cerr := closers[i].Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// wrap wraps the error with the name of the file being read and of its archive, if it is in one
+func (r *SentenceTagReader) wrap(err error) error {
+	archive := r.archive
+	if len(r.tars) > 0 {
+		archive = r.tars[len(r.tars)-1].name
+	}
+	if archive == "" || archive == r.name {
+		return err
+	}
+	return errors.Wrapf(err, "Unable to read %v in %v", r.name, archive)
+}
+
+// isTar checks for the magic of a POSIX tar header
+func isTar(r *bufio.Reader) bool {
+	header, _ := r.Peek(512)
+	return len(header) == 512 && bytes.HasPrefix(header[257:], []byte("ustar"))
+}
+
+// readAll reads all the sentences of the reader. The sentences read before an error are returned along with the error.
+func readAll(r *SentenceTagReader) ([]SentenceTag, error) {
+	sentences := make([]SentenceTag, 0)
+	for {
+		st, err := r.Next()
+		if err == io.EOF {
+			return sentences, nil
+		}
+		if err != nil {
+			return sentences, err
+		}
+		sentences = append(sentences, st)
+	}
+}
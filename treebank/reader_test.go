@@ -0,0 +1,138 @@
+package treebank
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func gzipped(t *testing.T, s string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSentenceTagReader(t *testing.T) {
+	assert := assert.New(t)
+	for _, r := range []io.Reader{strings.NewReader(udv2Conllu), bytes.NewReader(gzipped(t, udv2Conllu))} {
+		str := NewSentenceTagReader(context.Background(), r)
+		var ids []string
+		for {
+			st, err := str.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			ids = append(ids, st.ID())
+		}
+		assert.Equal([]string{"test-s1", "test-s2"}, ids)
+		_, err := str.Next()
+		assert.Equal(io.EOF, err, "the reader keeps returning io.EOF")
+		assert.Nil(str.Close())
+	}
+}
+
+func TestSentenceTagReader_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewSentenceTagReader(ctx, strings.NewReader(udv2Conllu))
+	if _, err := r.Next(); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	if _, err := r.Next(); err != context.Canceled {
+		t.Errorf("Expected context.Canceled. Got %v instead", err)
+	}
+}
+
+func TestOpenSentenceTagReader(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "treebank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// a gzipped file
+	gzName := filepath.Join(dir, "a.conllu.gz")
+	if err = ioutil.WriteFile(gzName, gzipped(t, udv2Conllu), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sentences, err := LoadUniversal(gzName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(sentences))
+
+	// a zip of a plain file, a gzipped file and a broken file
+	zipName := filepath.Join(dir, "b.zip")
+	f, err := os.Create(zipName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := zip.NewWriter(f)
+	files := []struct {
+		name     string
+		contents []byte
+	}{
+		{"a.conllu", []byte(udv2Conllu)},
+		{"b.conllu.gz", gzipped(t, sampleConllu)},
+		{"c.conllu", []byte("1\tA\ta\tX\tX\t_\tx\troot\t_\t_\n")},
+	}
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write(file.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	r, err := OpenSentenceTagReader(context.Background(), zipName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	count := 0
+	for {
+		_, err = r.Next()
+		if err != nil {
+			break
+		}
+		count++
+	}
+	sample, _ := ReadConllu(strings.NewReader(sampleConllu))
+	assert.Equal(2+len(sample), count)
+	if perr, ok := errors.Cause(err).(*ParseError); assert.True(ok, "%v", err) {
+		assert.Equal(1, perr.Line)
+		assert.Contains(err.Error(), "c.conllu")
+	}
+	assert.Nil(r.Close())
+
+	if _, err = OpenSentenceTagReader(context.Background(), filepath.Join(dir, "does-not-exist")); err == nil {
+		t.Error("Expected an error opening a file that doesn't exist")
+	}
+}
@@ -2,16 +2,125 @@ package treebank
 
 import (
 	"math/rand"
+	"strings"
 
+	"github.com/pkg/errors"
 	"github.com/sapariduo/lingua"
 )
 
-// SentenceTag is a struc that holds a sentence, tags, heads and labels
+// SentenceTag is a struc that holds a sentence, tags, heads and labels.
+//
+// Sentences read from a CONLLU file also keep their comments (e.g. "# sent_id = s1"), multiword tokens and empty nodes.
 type SentenceTag struct {
 	Sentence lingua.LexemeSentence
-	Tags     []lingua.POSTag
-	Heads    []int
-	Labels   []lingua.DependencyType
+	Tags     []lingua.POSTag         // the coarse POSTags of the tags of the TagSet: the UPOS for lingua.UniversalTags, the XPOS for any other TagSet
+	Heads    []int                   // the IDs of the heads of the words, with 0 for the root, and -1 for an unknown head (a HEAD of _)
+	Labels   []lingua.DependencyType // the coarse DependencyTypes of the DEPREL in the RelationSet
+
+	// the inventories the tags and the labels were read with. nil is lingua.UniversalTags and lingua.UniversalRelations
+	TagSet      *lingua.TagSet
+	RelationSet *lingua.RelationSet
+
+	// the columns of each word, as they are written in the file. An empty string is an underscore in the file.
+	// They may be empty if the SentenceTag wasn't read from a file.
+	Lemmas   []string
+	UPOS     []string
+	XPOS     []string
+	Features []lingua.Features // the FEATS of each word. It may be empty if the features aren't known
+	Deps     []string          // the DEPS of each word (the enhanced dependencies), as written in the file. See EnhancedGraph
+	Misc     []string
+
+	Comments   []string // the comment lines, as they are written in the file
+	MultiWords []MultiWord
+	EmptyNodes []EmptyNode
+
+	columns [][]string // the columns of each word as they were read, so that WriteConllu can reproduce the file
+}
+
+// Comment returns the value of a "# key = value" comment. A comment without a value (e.g. "# newpar") has an empty value.
+func (s SentenceTag) Comment(key string) (string, bool) {
+	for _, c := range s.Comments {
+		c = strings.TrimSpace(strings.TrimPrefix(c, "#"))
+		kv := strings.SplitN(c, "=", 2)
+		if strings.TrimSpace(kv[0]) != key {
+			continue
+		}
+		if len(kv) == 1 {
+			return "", true
+		}
+		return strings.TrimSpace(kv[1]), true
+	}
+	return "", false
+}
+
+// SetTags returns the tags of the words in the TagSet of the sentence. Words whose tag isn't in the TagSet (or has been changed
+// to a POSTag that the column doesn't read to) get the first tag of the TagSet with their POSTag.
+func (s SentenceTag) SetTags() []lingua.Tag {
+	ts := s.tagSet()
+	col := tagColumn(ts)
+	retVal := make([]lingua.Tag, len(s.Tags))
+	for i, t := range s.Tags {
+		if i < len(s.columns) {
+			if tag, ok := ts.Tag(s.columns[i][col]); ok && tag.POSTag() == t {
+				retVal[i] = tag
+				continue
+			}
+		}
+		retVal[i], _ = ts.FromPOSTag(t)
+	}
+	return retVal
+}
+
+// Relations returns the DEPREL of the words in the RelationSet of the sentence. See SetTags.
+func (s SentenceTag) Relations() []lingua.Relation {
+	rs := s.relationSet()
+	retVal := make([]lingua.Relation, len(s.Labels))
+	for i, dt := range s.Labels {
+		if i < len(s.columns) {
+			if rel, ok := rs.Relation(s.columns[i][7]); ok && rel.DependencyType() == dt {
+				retVal[i] = rel
+				continue
+			}
+		}
+		retVal[i], _ = rs.FromDependencyType(dt)
+	}
+	return retVal
+}
+
+func (s SentenceTag) tagSet() *lingua.TagSet {
+	if s.TagSet == nil {
+		return lingua.UniversalTags
+	}
+	return s.TagSet
+}
+
+func (s SentenceTag) relationSet() *lingua.RelationSet {
+	if s.RelationSet == nil {
+		return lingua.UniversalRelations
+	}
+	return s.RelationSet
+}
+
+// HasTree checks if the sentence has dependencies. A sentence whose heads are all unknown (e.g. read from a TSV file) has none.
+func (s SentenceTag) HasTree() bool {
+	for _, h := range s.Heads {
+		if h >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ID returns the sent_id of the sentence, if any
+func (s SentenceTag) ID() string {
+	id, _ := s.Comment("sent_id")
+	return id
+}
+
+// Text returns the text of the sentence, if any
+func (s SentenceTag) Text() string {
+	text, _ := s.Comment("text")
+	return text
 }
 
 func (s SentenceTag) AnnotatedSentence(f lingua.AnnotationFixer) lingua.AnnotatedSentence {
@@ -23,18 +132,25 @@ func (s SentenceTag) AnnotatedSentence(f lingua.AnnotationFixer) lingua.Annotate
 		a.Lexeme = lex
 		a.POSTag = s.Tags[i]
 		a.DependencyType = s.Labels[i]
+		if i < len(s.Features) {
+			a.Features = s.Features[i]
+		}
 
 		// should panic, because SentenceTag is only ever used during training
 		if err := a.Process(f); err != nil {
 			panic(err)
 		}
+		// the lemma of the treebank is better than any lemmatizer's
+		if i < len(s.Lemmas) && s.Lemmas[i] != "" {
+			a.Lemma = s.Lemmas[i]
+		}
 
 		retVal = append(retVal, a)
 	}
 
 	// add heads
 	for i, a := range retVal {
-		if i == 0 {
+		if i == 0 || s.Heads[i-1] < 0 {
 			continue
 		}
 		a.SetHead(retVal[s.Heads[i-1]])
@@ -45,6 +161,74 @@ func (s SentenceTag) AnnotatedSentence(f lingua.AnnotationFixer) lingua.Annotate
 	return retVal
 }
 
+// EnhancedGraph creates the enhanced dependency graph of the sentence from the DEPS of the words and of the empty nodes.
+// The AnnotatedSentence of the graph is the same as the one returned by AnnotatedSentence.
+func (s SentenceTag) EnhancedGraph(f lingua.AnnotationFixer) (*lingua.EnhancedGraph, error) {
+	g := lingua.NewEnhancedGraph(s.AnnotatedSentence(f))
+	for _, n := range s.EmptyNodes {
+		a, err := n.annotation(s.tagSet(), f)
+		if err != nil {
+			return nil, err
+		}
+		if err = g.AddEmptyNode(lingua.NodeID{Word: n.After, Empty: n.Index}, a); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, deps := range s.Deps {
+		if err := g.SetDeps(lingua.WordNode(i+1), deps); err != nil {
+			return nil, errors.Wrapf(err, "Word %d", i+1)
+		}
+	}
+	for _, n := range s.EmptyNodes {
+		if err := g.SetDeps(lingua.NodeID{Word: n.After, Empty: n.Index}, n.Deps); err != nil {
+			return nil, errors.Wrapf(err, "Empty node %v", n.ID())
+		}
+	}
+	return g, nil
+}
+
+// SetEnhanced replaces the DEPS of the words and the empty nodes of the sentence with the edges of the graph.
+// The empty nodes that are not in the graph are removed, and the ones that are only in the graph are added.
+func (s *SentenceTag) SetEnhanced(g *lingua.EnhancedGraph) {
+	s.Deps = make([]string, len(s.Sentence))
+	for i := range s.Sentence {
+		s.Deps[i] = g.Deps(lingua.WordNode(i + 1))
+	}
+
+	var empty []EmptyNode
+	for _, id := range g.EmptyNodes() {
+		n := emptyNodeOf(s.tagSet(), id, g.Node(id))
+		for _, old := range s.EmptyNodes {
+			if old.After == id.Word && old.Index == id.Empty {
+				n = old
+				break
+			}
+		}
+		n.Deps = g.Deps(id)
+		empty = append(empty, n)
+	}
+	s.EmptyNodes = empty
+}
+
+// ConvertToV2 converts the Labels of the sentence from UD v1 to UD v2, in place. See lingua.ToV2.
+func (s *SentenceTag) ConvertToV2() {
+	for i, dt := range s.Labels {
+		head := lingua.ROOT_TAG
+		if h := s.Heads[i]; h > 0 && h <= len(s.Tags) {
+			head = s.Tags[h-1]
+		}
+		s.Labels[i] = lingua.ToV2(dt, head, s.Tags[i])
+	}
+}
+
+// ConvertToV1 converts the Labels of the sentence from UD v2 to UD v1, in place. See lingua.ToV1.
+func (s *SentenceTag) ConvertToV1() {
+	for i, dt := range s.Labels {
+		s.Labels[i] = lingua.ToV1(dt)
+	}
+}
+
 func (s SentenceTag) Dependency(f lingua.AnnotationFixer) *lingua.Dependency {
 	sentence := s.AnnotatedSentence(f)
 	dep := sentence.Dependency()
@@ -56,10 +240,12 @@ func (s SentenceTag) String() string {
 	return s.Sentence.String()
 }
 
+// ShuffleSentenceTag shuffles the sentences in place, always in the same order. It doesn't change the global source of math/rand.
+// See SplitTreebank to split a treebank with a source of randomness of your own.
 func ShuffleSentenceTag(s []SentenceTag) []SentenceTag {
-	rand.Seed(1337)
+	r := rand.New(rand.NewSource(1337))
 	for i := range s {
-		j := rand.Intn(i + 1)
+		j := r.Intn(i + 1)
 		s[i], s[j] = s[j], s[i]
 	}
 
@@ -4,13 +4,18 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/sapariduo/lingua"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestSentenceTag(t *testing.T) {
 	assert := assert.New(t)
 	readr := strings.NewReader(sampleConllu)
-	st := ReadConllu(readr)[0]
+	sentences, err := ReadConllu(readr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := sentences[0]
 	t.Logf("%+v", st.Labels)
 	correctHeads := []int{2, 0, 2, 2, 4, 4, 9, 9, 2, 11, 9, 11, 2}
 	assert.Equal(correctHeads, st.Heads)
@@ -20,3 +25,23 @@ func TestSentenceTag(t *testing.T) {
 
 	assert.Equal(correctHeads, dep.Heads()[1:])
 }
+
+func TestSentenceTag_ConvertToV1(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(sampleConllu))
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := sentences[0]
+
+	st.ConvertToV1()
+	assert.Equal(lingua.Name, st.Labels[3])
+	assert.Equal(lingua.NSubjPass, st.Labels[6])
+	assert.Equal(lingua.NMod, st.Labels[10])
+
+	// obl of berusia (a VERB) is obl again; flat becomes flat:name
+	st.ConvertToV2()
+	assert.Equal(lingua.Flat_Name, st.Labels[3])
+	assert.Equal(lingua.NSubj_Pass, st.Labels[6])
+	assert.Equal(lingua.Obl, st.Labels[10])
+}
@@ -0,0 +1,387 @@
+package treebank
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Split is a split of a treebank into training, development and test sets. The sets are the indices of the sentences, in the order of the treebank.
+type Split struct {
+	Train, Dev, Test []int
+}
+
+// Keys returns a key for each sentence of a treebank, e.g. its document. See StratifyBy and GroupBy.
+type Keys func(sentences []SentenceTag) []string
+
+type splitter struct {
+	rand      *rand.Rand
+	dev, test float64
+	strata    Keys
+	groups    Keys
+}
+
+type splitOpt func(*splitter)
+
+// WithRand sets the source of randomness of the split. The default is a rand.Rand seeded with 1337, so that splits are reproducible.
+func WithRand(r *rand.Rand) splitOpt {
+	return func(s *splitter) {
+		s.rand = r
+	}
+}
+
+// WithRatios sets the share of the sentences in the development and test sets. The default is 0.1 and 0.1. KFold ignores it.
+func WithRatios(dev, test float64) splitOpt {
+	return func(s *splitter) {
+		s.dev = dev
+		s.test = test
+	}
+}
+
+// StratifyBy takes the same share of each stratum (the sentences with the same key) for each set, so that every set has the same mix of strata as the treebank.
+// The sets still get their shares of the whole treebank, even when the strata are too small to be split on their own.
+// See LengthStrata and Documents.
+func StratifyBy(k Keys) splitOpt {
+	return func(s *splitter) {
+		s.strata = k
+	}
+}
+
+// GroupBy keeps the sentences with the same key in the same set, e.g. the sentences of a document (see Documents).
+// Sentences with an empty key are on their own.
+func GroupBy(k Keys) splitOpt {
+	return func(s *splitter) {
+		s.groups = k
+	}
+}
+
+// Documents is the document of each sentence: the "# newdoc id" of the last sentence that starts a document.
+// Sentences before the first document have no document.
+func Documents(sentences []SentenceTag) []string {
+	retVal := make([]string, len(sentences))
+	var doc string
+	for i, s := range sentences {
+		if id, ok := s.Comment("newdoc id"); ok {
+			doc = id
+		} else if _, ok = s.Comment("newdoc"); ok {
+			doc = "#" + strconv.Itoa(i+1)
+		}
+		retVal[i] = doc
+	}
+	return retVal
+}
+
+// LengthStrata buckets the sentences by their lengths: 1 to size words, size+1 to 2*size words, and so on.
+// A size below 1 is rejected: the split fails with an error.
+func LengthStrata(size int) Keys {
+	return func(sentences []SentenceTag) []string {
+		if size < 1 {
+			return nil
+		}
+		retVal := make([]string, len(sentences))
+		for i, s := range sentences {
+			retVal[i] = strconv.Itoa((len(s.Sentence) - 1) / size)
+		}
+		return retVal
+	}
+}
+
+func newSplitter(opts ...splitOpt) *splitter {
+	s := &splitter{dev: 0.1, test: 0.1}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.rand == nil {
+		s.rand = rand.New(rand.NewSource(1337))
+	}
+	return s
+}
+
+// units returns the shuffled groups of sentences of each stratum, in the order of the strata
+func (s *splitter) units(sentences []SentenceTag) ([][][]int, error) {
+	strata, err := keys(s.strata, sentences)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to stratify")
+	}
+	groups, err := keys(s.groups, sentences)
+	if err != nil {
+		return nil, errors.Wrap(err, "Unable to group")
+	}
+
+	// a group is in the stratum of its first sentence
+	byStratum := make(map[string][][]int)
+	grouped := make(map[string]int) // the position of each group in its stratum
+	first := make(map[string]int)   // the first sentence of each group
+	var names []string
+	for i := range sentences {
+		g := groups[i]
+		if u, ok := grouped[g]; ok && g != "" {
+			stratum := strata[first[g]]
+			byStratum[stratum][u] = append(byStratum[stratum][u], i)
+			continue
+		}
+		if _, ok := byStratum[strata[i]]; !ok {
+			names = append(names, strata[i])
+		}
+		grouped[g], first[g] = len(byStratum[strata[i]]), i
+		byStratum[strata[i]] = append(byStratum[strata[i]], []int{i})
+	}
+
+	sort.Strings(names)
+	retVal := make([][][]int, len(names))
+	for i, name := range names {
+		units := byStratum[name]
+		s.rand.Shuffle(len(units), func(a, b int) { units[a], units[b] = units[b], units[a] })
+		retVal[i] = units
+	}
+	return retVal, nil
+}
+
+func keys(k Keys, sentences []SentenceTag) ([]string, error) {
+	if k == nil {
+		return make([]string, len(sentences)), nil
+	}
+	retVal := k(sentences)
+	if len(retVal) != len(sentences) {
+		return nil, errors.Errorf("Expected %d keys. Got %d", len(sentences), len(retVal))
+	}
+	return retVal, nil
+}
+
+// order returns the units of all the strata, ordered by where their middles are in their strata, so that taking the units in order
+// takes the same share of each stratum. Units at the same place are in a random order.
+func (s *splitter) order(strata [][][]int) (units [][]int, total int) {
+	type placed struct {
+		unit []int
+		at   float64
+	}
+	var all []placed
+	for _, stratum := range strata {
+		size := 0
+		for _, u := range stratum {
+			size += len(u)
+		}
+		before := 0
+		for _, u := range stratum {
+			all = append(all, placed{u, (float64(before) + float64(len(u))/2) / float64(size)})
+			before += len(u)
+		}
+		total += size
+	}
+	s.rand.Shuffle(len(all), func(a, b int) { all[a], all[b] = all[b], all[a] })
+	sort.SliceStable(all, func(a, b int) bool { return all[a].at < all[b].at })
+
+	units = make([][]int, len(all))
+	for i, p := range all {
+		units[i] = p.unit
+	}
+	return units, total
+}
+
+// target is the number of sentences of a set with the ratio: at least 1 if the ratio isn't 0, unless there are too few sentences
+func target(ratio float64, total int) int {
+	n := int(ratio*float64(total) + 0.5)
+	if n == 0 && ratio > 0 && total > 2 {
+		n = 1
+	}
+	return n
+}
+
+// SplitTreebank splits the sentences at random into training, development and test sets.
+//
+// These are the options:
+//		WithRand (default: seeded with 1337)
+//		WithRatios (default: 0.1, 0.1)
+//		StratifyBy
+//		GroupBy
+func SplitTreebank(sentences []SentenceTag, opts ...splitOpt) (Split, error) {
+	s := newSplitter(opts...)
+	if s.dev < 0 || s.test < 0 || s.dev+s.test > 1 {
+		return Split{}, errors.Errorf("Invalid ratios %v and %v", s.dev, s.test)
+	}
+	strata, err := s.units(sentences)
+	if err != nil {
+		return Split{}, err
+	}
+
+	// the sets are filled in order, each up to its share of all the sentences
+	units, total := s.order(strata)
+	test, dev := target(s.test, total), target(s.dev, total)
+	var retVal Split
+	for _, unit := range units {
+		switch {
+		case len(retVal.Test) < test:
+			retVal.Test = append(retVal.Test, unit...)
+		case len(retVal.Dev) < dev:
+			retVal.Dev = append(retVal.Dev, unit...)
+		default:
+			retVal.Train = append(retVal.Train, unit...)
+		}
+	}
+	sort.Ints(retVal.Train)
+	sort.Ints(retVal.Dev)
+	sort.Ints(retVal.Test)
+	return retVal, nil
+}
+
+// Folds iterates over the k splits of a k-fold cross-validation:
+//		for folds.Next() {
+//			split := folds.Split()
+//			...
+//		}
+type Folds struct {
+	folds [][]int
+	i     int
+}
+
+// KFold splits the sentences at random into k folds of the same size. Each Split of the Folds tests on one fold, and trains on the others.
+// It has no development set.
+//
+// These are the options:
+//		WithRand (default: seeded with 1337)
+//		StratifyBy
+//		GroupBy
+func KFold(sentences []SentenceTag, k int, opts ...splitOpt) (*Folds, error) {
+	if k < 2 {
+		return nil, errors.Errorf("Expected at least 2 folds. Got %d", k)
+	}
+	s := newSplitter(opts...)
+	strata, err := s.units(sentences)
+	if err != nil {
+		return nil, err
+	}
+
+	// each unit goes to the fold of its middle among all the sentences
+	units, total := s.order(strata)
+	folds := make([][]int, k)
+	before := 0
+	for _, unit := range units {
+		f := int((float64(before) + float64(len(unit))/2) / float64(total) * float64(k))
+		folds[f] = append(folds[f], unit...)
+		before += len(unit)
+	}
+	for _, f := range folds {
+		sort.Ints(f)
+	}
+	return &Folds{folds: folds, i: -1}, nil
+}
+
+// Next moves to the next Split. It returns false after the last one.
+func (f *Folds) Next() bool {
+	if f.i < len(f.folds) {
+		f.i++
+	}
+	return f.i < len(f.folds)
+}
+
+// Fold returns the index of the current Split
+func (f *Folds) Fold() int { return f.i }
+
+// Split returns the current Split
+func (f *Folds) Split() Split {
+	var retVal Split
+	for i, fold := range f.folds {
+		if i == f.i {
+			retVal.Test = append(retVal.Test, fold...)
+		} else {
+			retVal.Train = append(retVal.Train, fold...)
+		}
+	}
+	sort.Ints(retVal.Train)
+	return retVal
+}
+
+// Sentences returns the sentences of each set
+func (s Split) Sentences(sentences []SentenceTag) (train, dev, test []SentenceTag) {
+	pick := func(indices []int) []SentenceTag {
+		retVal := make([]SentenceTag, len(indices))
+		for i, j := range indices {
+			retVal[i] = sentences[j]
+		}
+		return retVal
+	}
+	return pick(s.Train), pick(s.Dev), pick(s.Test)
+}
+
+// sentenceIDs returns the sent_id of each sentence, or its position (starting from 1) if it has none, as WriteAnnotatedConllu does
+func sentenceIDs(sentences []SentenceTag) (map[string]int, []string, error) {
+	index := make(map[string]int, len(sentences))
+	ids := make([]string, len(sentences))
+	for i, s := range sentences {
+		id := s.ID()
+		if id == "" {
+			id = strconv.Itoa(i + 1)
+		}
+		if j, ok := index[id]; ok {
+			return nil, nil, errors.Errorf("Sentences %d and %d have the same ID %q", j+1, i+1, id)
+		}
+		index[id] = i
+		ids[i] = id
+	}
+	return index, ids, nil
+}
+
+// WriteManifest writes the split as a manifest: a line with the set ("train", "dev" or "test") and the ID of each sentence, separated by a tab.
+// The IDs are the sent_id of the sentences, or their positions (starting from 1) if they have none. ReadManifest reads the split back.
+func (s Split) WriteManifest(w io.Writer, sentences []SentenceTag) error {
+	_, ids, err := sentenceIDs(sentences)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, set := range []struct {
+		name    string
+		indices []int
+	}{{"train", s.Train}, {"dev", s.Dev}, {"test", s.Test}} {
+		for _, i := range set.indices {
+			if i < 0 || i >= len(ids) {
+				return errors.Errorf("No sentence %d", i)
+			}
+			fmt.Fprintf(bw, "%s\t%s\n", set.name, ids[i])
+		}
+	}
+	return bw.Flush()
+}
+
+// ReadManifest reads a manifest written by WriteManifest, and returns the split of the sentences it describes.
+// Sentences that aren't in the manifest aren't in any set.
+func ReadManifest(r io.Reader, sentences []SentenceTag) (Split, error) {
+	var retVal Split
+	index, _, err := sentenceIDs(sentences)
+	if err != nil {
+		return retVal, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		l := strings.TrimRight(scanner.Text(), "\r")
+		if l == "" {
+			continue
+		}
+		cols := strings.SplitN(l, "\t", 2)
+		if len(cols) != 2 {
+			return retVal, parseErrorf(line, "Expected a set and an ID. Got %q", l)
+		}
+		i, ok := index[cols[1]]
+		if !ok {
+			return retVal, parseErrorf(line, "Unknown sentence %q", cols[1])
+		}
+		switch cols[0] {
+		case "train":
+			retVal.Train = append(retVal.Train, i)
+		case "dev":
+			retVal.Dev = append(retVal.Dev, i)
+		case "test":
+			retVal.Test = append(retVal.Test, i)
+		default:
+			return retVal, parseErrorf(line, "Unknown set %q", cols[0])
+		}
+	}
+	return retVal, scanner.Err()
+}
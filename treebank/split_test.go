@@ -0,0 +1,203 @@
+package treebank
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+// splitSentences makes n sentences in documents of 5 sentences each. Sentence i has i%7+1 words.
+func splitSentences(n int) []SentenceTag {
+	sentences := make([]SentenceTag, n)
+	for i := range sentences {
+		s := &sentences[i]
+		if i%5 == 0 {
+			s.Comments = append(s.Comments, fmt.Sprintf("# newdoc id = d%d", i/5))
+		}
+		s.Comments = append(s.Comments, fmt.Sprintf("# sent_id = s%d", i))
+		for j := 0; j <= i%7; j++ {
+			s.Sentence = append(s.Sentence, lingua.Lexeme{Value: "w"})
+		}
+	}
+	return sentences
+}
+
+func TestSplitTreebank(t *testing.T) {
+	assert := assert.New(t)
+	sentences := splitSentences(100)
+
+	split, err := SplitTreebank(sentences, WithRand(rand.New(rand.NewSource(1))))
+	if !assert.NoError(err) {
+		return
+	}
+	assert.Len(split.Train, 80)
+	assert.Len(split.Dev, 10)
+	assert.Len(split.Test, 10)
+	seen := make(map[int]bool)
+	for _, i := range append(append(split.Train, split.Dev...), split.Test...) {
+		assert.False(seen[i])
+		seen[i] = true
+	}
+
+	again, _ := SplitTreebank(sentences, WithRand(rand.New(rand.NewSource(1))))
+	assert.Equal(split, again)
+	other, _ := SplitTreebank(sentences, WithRand(rand.New(rand.NewSource(2))))
+	assert.NotEqual(split, other)
+
+	_, err = SplitTreebank(sentences, WithRatios(0.6, 0.6))
+	assert.Error(err)
+}
+
+func TestSplitTreebank_Group(t *testing.T) {
+	assert := assert.New(t)
+	sentences := splitSentences(100)
+	docs := Documents(sentences)
+	assert.Equal("d0", docs[4])
+	assert.Equal("d1", docs[5])
+
+	split, err := SplitTreebank(sentences, GroupBy(Documents), WithRatios(0.2, 0.2))
+	if !assert.NoError(err) {
+		return
+	}
+	set := make(map[string]string)
+	for name, indices := range map[string][]int{"train": split.Train, "dev": split.Dev, "test": split.Test} {
+		for _, i := range indices {
+			if s, ok := set[docs[i]]; ok {
+				assert.Equal(s, name, "document %v is in several sets", docs[i])
+			}
+			set[docs[i]] = name
+		}
+	}
+	assert.Len(split.Test, 20)
+	assert.Len(split.Dev, 20)
+}
+
+func TestSplitTreebank_Stratify(t *testing.T) {
+	assert := assert.New(t)
+	sentences := splitSentences(140)
+
+	split, err := SplitTreebank(sentences, StratifyBy(LengthStrata(1)))
+	if !assert.NoError(err) {
+		return
+	}
+	// each of the 7 lengths has 20 sentences, so 2 of each are tested
+	lengths := make(map[int]int)
+	for _, i := range split.Test {
+		lengths[len(sentences[i].Sentence)]++
+	}
+	assert.Equal(map[int]int{1: 2, 2: 2, 3: 2, 4: 2, 5: 2, 6: 2, 7: 2}, lengths)
+
+	_, err = SplitTreebank(sentences, StratifyBy(func([]SentenceTag) []string { return nil }))
+	assert.Error(err)
+	_, err = SplitTreebank(sentences, StratifyBy(LengthStrata(0)))
+	assert.Error(err)
+}
+
+func TestSplitTreebank_Sizes(t *testing.T) {
+	assert := assert.New(t)
+
+	var sizesTests = []struct {
+		name            string
+		n               int
+		opts            []splitOpt
+		train, dev, tst int
+	}{
+		{"by 5 words", 40, []splitOpt{StratifyBy(LengthStrata(5))}, 32, 4, 4},
+		{"by length", 40, []splitOpt{StratifyBy(LengthStrata(1))}, 32, 4, 4},
+		{"by document", 40, []splitOpt{StratifyBy(Documents), WithRatios(0.2, 0.2)}, 24, 8, 8},
+		{"few sentences", 5, nil, 3, 1, 1},
+		{"two sentences", 2, nil, 2, 0, 0},
+		{"no test set", 40, []splitOpt{StratifyBy(LengthStrata(1)), WithRatios(0.25, 0)}, 30, 10, 0},
+	}
+	for _, tt := range sizesTests {
+		sentences := splitSentences(tt.n)
+		split, err := SplitTreebank(sentences, tt.opts...)
+		if !assert.NoError(err, tt.name) {
+			continue
+		}
+		assert.Len(split.Train, tt.train, tt.name)
+		assert.Len(split.Dev, tt.dev, tt.name)
+		assert.Len(split.Test, tt.tst, tt.name)
+	}
+
+	// 7 lengths of 20 sentences, and 10% of each in the test set
+	sentences := splitSentences(140)
+	folds, err := KFold(sentences, 10, StratifyBy(LengthStrata(1)))
+	if !assert.NoError(err) {
+		return
+	}
+	for folds.Next() {
+		lengths := make(map[int]int)
+		for _, i := range folds.Split().Test {
+			lengths[len(sentences[i].Sentence)]++
+		}
+		assert.Equal(map[int]int{1: 2, 2: 2, 3: 2, 4: 2, 5: 2, 6: 2, 7: 2}, lengths, "fold %d", folds.Fold())
+	}
+}
+
+func TestKFold(t *testing.T) {
+	assert := assert.New(t)
+	sentences := splitSentences(50)
+
+	folds, err := KFold(sentences, 5, GroupBy(Documents))
+	if !assert.NoError(err) {
+		return
+	}
+	tested := make(map[int]int)
+	n := 0
+	for folds.Next() {
+		assert.Equal(n, folds.Fold())
+		split := folds.Split()
+		assert.Len(split.Test, 10)
+		assert.Len(split.Train, 40)
+		assert.Empty(split.Dev)
+		for _, i := range split.Test {
+			tested[i]++
+		}
+		n++
+	}
+	assert.Equal(5, n)
+	assert.Len(tested, 50)
+	assert.False(folds.Next())
+
+	_, err = KFold(sentences, 1)
+	assert.Error(err)
+}
+
+func TestManifest(t *testing.T) {
+	assert := assert.New(t)
+	sentences := splitSentences(30)
+	sentences[3].Comments = nil // identified by its position
+
+	split, err := SplitTreebank(sentences, WithRatios(0.2, 0.2))
+	if !assert.NoError(err) {
+		return
+	}
+	var buf bytes.Buffer
+	if !assert.NoError(split.WriteManifest(&buf, sentences)) {
+		return
+	}
+	assert.Contains(buf.String(), "\t4\n")
+
+	read, err := ReadManifest(&buf, sentences)
+	assert.NoError(err)
+	assert.Equal(split, read)
+
+	train, dev, test := read.Sentences(sentences)
+	assert.Equal(len(split.Train), len(train))
+	assert.Equal(sentences[split.Dev[0]].ID(), dev[0].ID())
+	assert.Len(test, 6)
+
+	_, err = ReadManifest(strings.NewReader("train\ts1\nvalid\ts2\n"), sentences)
+	assert.EqualError(err, "Line 2: Unknown set \"valid\"")
+	_, err = ReadManifest(strings.NewReader("train\tnope\n"), sentences)
+	assert.Error(err)
+
+	sentences[1].Comments = []string{"# sent_id = s0"}
+	assert.Error(split.WriteManifest(&buf, sentences))
+}
@@ -0,0 +1,327 @@
+package treebank
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Vocabulary is a list of known words, such as a *corpus.Corpus.
+type Vocabulary interface {
+	Id(word string) (int, bool)
+}
+
+// Stats are the statistics of a treebank, to spot broken data before training on it. See ComputeStats.
+type Stats struct {
+	Sentences int
+	Tokens    int
+	Types     int     // the number of distinct words
+	TypeToken float64 // Types / Tokens
+
+	Tags             map[string]int // the number of words with each tag, by the name of the tag in the TagSet of the sentence
+	Relations        map[string]int // the number of words with each relation, by the name of the relation in the RelationSet of the sentence
+	UnknownTags      map[string]int // the tags that aren't in the TagSet of the sentence (and were read as X)
+	UnknownRelations map[string]int // the relations that aren't in the RelationSet of the sentence (and were read as NoDepType)
+
+	MinLength, MaxLength int
+	MeanLength           float64
+	Lengths              []LengthBucket // the histogram of the sentence lengths
+
+	NoTree             int     // the number of sentences without dependencies (see SentenceTag.HasTree). The tree statistics skip them
+	NonProjective      int     // the number of sentences with crossing arcs
+	NonProjectiveShare float64 // NonProjective / the sentences with trees
+	InvalidTrees       int     // the number of sentences whose heads aren't a tree: without a root, with several roots, or with a cycle
+
+	ArcLengths map[string]float64 // the average distance between the head and the dependent of each relation. Root arcs aren't counted
+
+	// the out of vocabulary words, if the Stats were computed WithVocabulary
+	OOVTokens, OOVTypes       int
+	OOVTokenRate, OOVTypeRate float64
+}
+
+// LengthBucket is a bucket of the histogram of the sentence lengths: the number of sentences with Min to Max words.
+type LengthBucket struct {
+	Min, Max int
+	Count    int
+}
+
+type statsConfig struct {
+	vocab      Vocabulary
+	bucketSize int
+}
+
+type statsOpt func(*statsConfig)
+
+// WithVocabulary computes the out of vocabulary rates of the words against the Vocabulary (e.g. a *corpus.Corpus).
+func WithVocabulary(v Vocabulary) statsOpt {
+	return func(c *statsConfig) {
+		c.vocab = v
+	}
+}
+
+// WithBucketSize sets the size of the buckets of the histogram of the sentence lengths. The default is 10.
+func WithBucketSize(size int) statsOpt {
+	return func(c *statsConfig) {
+		if size > 0 {
+			c.bucketSize = size
+		}
+	}
+}
+
+// ComputeStats computes the Stats of the sentences.
+//
+// These are the options:
+//		WithVocabulary
+//		WithBucketSize (default: 10)
+func ComputeStats(sentences []SentenceTag, opts ...statsOpt) *Stats {
+	c := &statsConfig{bucketSize: 10}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	s := &Stats{
+		Sentences:        len(sentences),
+		Tags:             make(map[string]int),
+		Relations:        make(map[string]int),
+		UnknownTags:      make(map[string]int),
+		UnknownRelations: make(map[string]int),
+		ArcLengths:       make(map[string]float64),
+	}
+
+	types := make(map[string]bool)
+	arcs := make(map[string]int)
+	buckets := make(map[int]int)
+	for i, st := range sentences {
+		n := len(st.Sentence)
+		s.Tokens += n
+		if i == 0 || n < s.MinLength {
+			s.MinLength = n
+		}
+		if n > s.MaxLength {
+			s.MaxLength = n
+		}
+		buckets[(n-1)/c.bucketSize]++
+
+		for _, lex := range st.Sentence {
+			if !types[lex.Value] && c.vocab != nil {
+				if _, ok := c.vocab.Id(lex.Value); !ok {
+					s.OOVTypes++
+				}
+			}
+			types[lex.Value] = true
+			if c.vocab != nil {
+				if _, ok := c.vocab.Id(lex.Value); !ok {
+					s.OOVTokens++
+				}
+			}
+		}
+
+		s.countTags(st)
+		if !st.HasTree() {
+			s.NoTree++
+			continue
+		}
+		s.countRelations(st, arcs)
+		if isNonProjective(st.Heads) {
+			s.NonProjective++
+		}
+		if !isTree(st.Heads) {
+			s.InvalidTrees++
+		}
+	}
+
+	s.Types = len(types)
+	if s.Tokens > 0 {
+		s.TypeToken = float64(s.Types) / float64(s.Tokens)
+		s.OOVTokenRate = float64(s.OOVTokens) / float64(s.Tokens)
+	}
+	if s.Types > 0 {
+		s.OOVTypeRate = float64(s.OOVTypes) / float64(s.Types)
+	}
+	if s.Sentences > 0 {
+		s.MeanLength = float64(s.Tokens) / float64(s.Sentences)
+	}
+	if trees := s.Sentences - s.NoTree; trees > 0 {
+		s.NonProjectiveShare = float64(s.NonProjective) / float64(trees)
+	}
+	for rel, total := range s.ArcLengths {
+		s.ArcLengths[rel] = total / float64(arcs[rel])
+	}
+
+	var keys []int
+	for b := range buckets {
+		keys = append(keys, b)
+	}
+	sort.Ints(keys)
+	for _, b := range keys {
+		s.Lengths = append(s.Lengths, LengthBucket{Min: b*c.bucketSize + 1, Max: (b + 1) * c.bucketSize, Count: buckets[b]})
+	}
+	return s
+}
+
+// countTags counts the tags of the sentence
+func (s *Stats) countTags(st SentenceTag) {
+	ts := st.tagSet()
+	for i, t := range st.SetTags() {
+		s.Tags[t.String()]++
+
+		var name string
+		if tagColumn(ts) == 3 && i < len(st.UPOS) {
+			name = st.UPOS[i]
+		} else if tagColumn(ts) == 4 && i < len(st.XPOS) {
+			name = st.XPOS[i]
+		}
+		if _, ok := ts.Tag(name); name != "" && !ok {
+			s.UnknownTags[name]++
+		}
+	}
+}
+
+// countRelations counts the relations of the sentence, and adds up the arc lengths of each relation
+func (s *Stats) countRelations(st SentenceTag, arcs map[string]int) {
+	rs := st.relationSet()
+	for i, r := range st.Relations() {
+		rel := r.String()
+		s.Relations[rel]++
+		if i < len(st.columns) {
+			if name := st.columns[i][7]; name != "_" {
+				if _, ok := rs.Relation(name); !ok {
+					s.UnknownRelations[name]++
+				}
+			}
+		}
+
+		if h := st.Heads[i]; h > 0 {
+			d := h - (i + 1)
+			if d < 0 {
+				d = -d
+			}
+			s.ArcLengths[rel] += float64(d)
+			arcs[rel]++
+		}
+	}
+}
+
+// isNonProjective checks if any two arcs cross. The heads are 1-indexed, with 0 as the root.
+func isNonProjective(heads []int) bool {
+	span := func(i int) (int, int) {
+		h, d := heads[i], i+1
+		if h < d {
+			return h, d
+		}
+		return d, h
+	}
+	for i := range heads {
+		a, b := span(i)
+		for j := range heads {
+			if c, d := span(j); a < c && c < b && b < d {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTree checks that the heads have a single root, and no cycles
+func isTree(heads []int) bool {
+	roots := 0
+	for _, h := range heads {
+		if h == 0 {
+			roots++
+		}
+	}
+	if roots != 1 {
+		return false
+	}
+
+	for i := range heads {
+		// a word that isn't under the root after len(heads) steps is in a cycle
+		k := i + 1
+		for steps := 0; k != 0 && steps <= len(heads); steps++ {
+			if k < 1 || k > len(heads) {
+				return false
+			}
+			k = heads[k-1]
+		}
+		if k != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteJSON writes the Stats as indented JSON
+func (s *Stats) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(s)
+}
+
+// WriteText writes the Stats as a human readable report. The distributions are sorted by their counts.
+func (s *Stats) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Sentences\t%d\n", s.Sentences)
+	fmt.Fprintf(tw, "Tokens\t%d\n", s.Tokens)
+	fmt.Fprintf(tw, "Types\t%d\n", s.Types)
+	fmt.Fprintf(tw, "Type/token ratio\t%.4f\n", s.TypeToken)
+	fmt.Fprintf(tw, "Sentence length\tmin %d, max %d, mean %.2f\n", s.MinLength, s.MaxLength, s.MeanLength)
+	if s.NoTree > 0 {
+		fmt.Fprintf(tw, "Without trees\t%d\n", s.NoTree)
+	}
+	fmt.Fprintf(tw, "Non-projective\t%d (%.2f%%)\n", s.NonProjective, 100*s.NonProjectiveShare)
+	fmt.Fprintf(tw, "Invalid trees\t%d\n", s.InvalidTrees)
+	fmt.Fprintf(tw, "OOV tokens\t%d (%.2f%%)\n", s.OOVTokens, 100*s.OOVTokenRate)
+	fmt.Fprintf(tw, "OOV types\t%d (%.2f%%)\n", s.OOVTypes, 100*s.OOVTypeRate)
+
+	fmt.Fprintf(tw, "\nSentence lengths\n")
+	for _, b := range s.Lengths {
+		fmt.Fprintf(tw, "%d-%d\t%d\n", b.Min, b.Max, b.Count)
+	}
+
+	writeCounts(tw, "Tags", s.Tags)
+	writeCounts(tw, "Unknown tags", s.UnknownTags)
+	writeCounts(tw, "Relations", s.Relations)
+	writeCounts(tw, "Unknown relations", s.UnknownRelations)
+
+	if len(s.ArcLengths) > 0 {
+		fmt.Fprintf(tw, "\nAverage arc lengths\n")
+		for _, rel := range sortedKeys(s.Relations) {
+			if l, ok := s.ArcLengths[rel]; ok {
+				fmt.Fprintf(tw, "%s\t%.2f\n", rel, l)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func writeCounts(w io.Writer, title string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	fmt.Fprintf(w, "\n%s\n", title)
+	for _, k := range sortedKeys(counts) {
+		fmt.Fprintf(w, "%s\t%d\t%.2f%%\n", k, counts[k], 100*float64(counts[k])/float64(total))
+	}
+}
+
+// sortedKeys returns the keys by decreasing counts, then alphabetically
+func sortedKeys(counts map[string]int) []string {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return strings.Compare(keys[i], keys[j]) < 0
+	})
+	return keys
+}
@@ -0,0 +1,108 @@
+package treebank
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// statsConllu has an unknown tag (NOUNX) and an unknown relation (foo), and its second sentence is non-projective
+const statsConllu = `1	Ani	Ani	PROPN	_	_	2	nsubj	_	_
+2	makan	makan	VERB	_	_	0	root	_	_
+3	nasi	nasi	NOUNX	_	_	2	obj	_	_
+
+1	nasi	nasi	NOUN	_	_	3	obj	_	_
+2	Ani	Ani	PROPN	_	_	4	foo	_	_
+3	makan	makan	VERB	_	_	0	root	_	_
+4	tadi	tadi	ADV	_	_	3	advmod	_	_
+5	.	.	PUNCT	_	_	3	punct	_	_`
+
+type vocabulary map[string]int
+
+func (v vocabulary) Id(word string) (int, bool) {
+	id, ok := v[word]
+	return id, ok
+}
+
+func TestComputeStats(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(statsConllu))
+	if !assert.NoError(err) {
+		return
+	}
+
+	s := ComputeStats(sentences, WithVocabulary(vocabulary{"Ani": 1, "makan": 2}), WithBucketSize(4))
+	assert.Equal(2, s.Sentences)
+	assert.Equal(8, s.Tokens)
+	assert.Equal(5, s.Types)
+	assert.InDelta(5.0/8, s.TypeToken, 1e-9)
+	assert.Equal(3, s.MinLength)
+	assert.Equal(5, s.MaxLength)
+	assert.Equal(4.0, s.MeanLength)
+	assert.Equal([]LengthBucket{{1, 4, 1}, {5, 8, 1}}, s.Lengths)
+
+	assert.Equal(2, s.Tags["PROPN"])
+	assert.Equal(1, s.Tags["X"])
+	assert.Equal(map[string]int{"NOUNX": 1}, s.UnknownTags)
+	assert.Equal(2, s.Relations["root"])
+	assert.Equal(map[string]int{"foo": 1}, s.UnknownRelations)
+
+	// 1 <- 3 crosses 2 -> 4
+	assert.Equal(1, s.NonProjective)
+	assert.Equal(0.5, s.NonProjectiveShare)
+	assert.Equal(0, s.InvalidTrees)
+	assert.Equal(1.5, s.ArcLengths["obj"])
+	assert.Equal(1.0, s.ArcLengths["nsubj"])
+	_, ok := s.ArcLengths["root"]
+	assert.False(ok)
+
+	assert.Equal(4, s.OOVTokens)
+	assert.Equal(3, s.OOVTypes)
+	assert.Equal(0.5, s.OOVTokenRate)
+	assert.Equal(0.6, s.OOVTypeRate)
+
+	var buf bytes.Buffer
+	assert.NoError(s.WriteText(&buf))
+	assert.Contains(buf.String(), "Unknown relations")
+	assert.Contains(buf.String(), "NOUNX")
+
+	buf.Reset()
+	assert.NoError(s.WriteJSON(&buf))
+	var read Stats
+	assert.NoError(json.Unmarshal(buf.Bytes(), &read))
+	assert.Equal(*s, read)
+}
+
+func TestComputeStats_KnownVocabulary(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(statsConllu))
+	if !assert.NoError(err) {
+		return
+	}
+
+	v := make(vocabulary)
+	for _, st := range sentences {
+		for _, lex := range st.Sentence {
+			v[lex.Value] = len(v)
+		}
+	}
+	s := ComputeStats(sentences, WithVocabulary(v))
+	assert.Equal(0, s.OOVTokens)
+	assert.Equal(0, s.OOVTypes)
+	assert.Equal(0.0, s.OOVTypeRate)
+
+	// without a Vocabulary, nothing is out of vocabulary
+	assert.Equal(0, ComputeStats(sentences).OOVTokens)
+}
+
+func TestIsTree(t *testing.T) {
+	assert := assert.New(t)
+	assert.True(isTree([]int{2, 0, 2}))
+	assert.False(isTree([]int{2, 0, 0}))
+	assert.False(isTree([]int{2, 3, 1}))
+	assert.False(isTree([]int{0, 3, 2}))
+	assert.False(isTree([]int{0, 5}))
+}
@@ -0,0 +1,279 @@
+package treebank
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+// Tree is a constituency tree, as found in the Penn Treebank: "(S (NP (DT The) (NN cat)) (VP (VBD sat)))".
+//
+// The leaves are the words, and have no children. The parents of the leaves (the preterminals) are labelled with the tags of the words.
+// The labels of the other nodes are their categories, with their function tags and indices (e.g. NP-SBJ-1).
+type Tree struct {
+	Label    string
+	Children []*Tree
+}
+
+// IsLeaf checks if the node is a word
+func (t *Tree) IsLeaf() bool { return len(t.Children) == 0 }
+
+// IsPreterminal checks if the node is the tag of a word
+func (t *Tree) IsPreterminal() bool { return len(t.Children) == 1 && t.Children[0].IsLeaf() }
+
+// Category returns the label without its function tags and indices: NP-SBJ-1 is NP. Labels that start with "-" (e.g. -NONE-) are returned as they are.
+func (t *Tree) Category() string {
+	if strings.HasPrefix(t.Label, "-") {
+		return t.Label
+	}
+	if i := strings.IndexAny(t.Label, "-="); i > 0 {
+		return t.Label[:i]
+	}
+	return t.Label
+}
+
+// FunctionTags returns the function tags of the label: NP-SBJ-1 has SBJ.
+func (t *Tree) FunctionTags() []string {
+	if strings.HasPrefix(t.Label, "-") {
+		return nil
+	}
+	var retVal []string
+	fields := strings.FieldsFunc(t.Label, func(r rune) bool { return r == '-' || r == '=' })
+	for i, f := range fields {
+		if i > 0 && !isDigits(f) {
+			retVal = append(retVal, f)
+		}
+	}
+	return retVal
+}
+
+// HasFunctionTag checks if the label has the function tag
+func (t *Tree) HasFunctionTag(tag string) bool {
+	for _, f := range t.FunctionTags() {
+		if f == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Preterminals returns the preterminals of the tree, from left to right.
+func (t *Tree) Preterminals() []*Tree {
+	if t.IsPreterminal() {
+		return []*Tree{t}
+	}
+	var retVal []*Tree
+	for _, c := range t.Children {
+		retVal = append(retVal, c.Preterminals()...)
+	}
+	return retVal
+}
+
+// Words returns the words of the tree
+func (t *Tree) Words() []string {
+	pts := t.Preterminals()
+	retVal := make([]string, len(pts))
+	for i, pt := range pts {
+		retVal[i] = pt.Children[0].Label
+	}
+	return retVal
+}
+
+// Tags returns the tags of the words of the tree
+func (t *Tree) Tags() []string {
+	pts := t.Preterminals()
+	retVal := make([]string, len(pts))
+	for i, pt := range pts {
+		retVal[i] = pt.Label
+	}
+	return retVal
+}
+
+// WithoutEmpty returns a copy of the tree without the empty elements (-NONE-), and without the constituents that only had empty elements.
+// It returns nil if the whole tree is empty.
+func (t *Tree) WithoutEmpty() *Tree {
+	if t.Label == "-NONE-" {
+		return nil
+	}
+	if t.IsLeaf() {
+		return &Tree{Label: t.Label}
+	}
+	retVal := &Tree{Label: t.Label}
+	for _, c := range t.Children {
+		if c = c.WithoutEmpty(); c != nil {
+			retVal.Children = append(retVal.Children, c)
+		}
+	}
+	if len(retVal.Children) == 0 {
+		return nil
+	}
+	return retVal
+}
+
+// String returns the tree in the bracketed format of the Penn Treebank, on one line.
+func (t *Tree) String() string {
+	var buf bytes.Buffer
+	t.write(&buf)
+	return buf.String()
+}
+
+func (t *Tree) write(buf *bytes.Buffer) {
+	if t.IsLeaf() {
+		buf.WriteString(t.Label)
+		return
+	}
+	buf.WriteByte('(')
+	buf.WriteString(t.Label)
+	for _, c := range t.Children {
+		buf.WriteByte(' ')
+		c.write(buf)
+	}
+	buf.WriteByte(')')
+}
+
+// ParseTree parses a tree in the bracketed format of the Penn Treebank
+func ParseTree(s string) (*Tree, error) {
+	trees, err := ReadPTB(strings.NewReader(s))
+	if err != nil {
+		return nil, err
+	}
+	if len(trees) != 1 {
+		return nil, errors.Errorf("Expected 1 tree. Got %d", len(trees))
+	}
+	return trees[0], nil
+}
+
+// ReadPTB reads the trees of a file in the bracketed format of the Penn Treebank (e.g. the .mrg files).
+// A tree may span several lines, and the outermost brackets may be unlabelled: "( (S ...) )".
+// Malformed trees are returned as a *ParseError.
+func ReadPTB(r io.Reader) ([]*Tree, error) {
+	p := &ptbReader{r: bufio.NewReader(r), line: 1}
+	var trees []*Tree
+	for {
+		tok, err := p.token()
+		if err == io.EOF {
+			return trees, nil
+		}
+		if err != nil {
+			return trees, err
+		}
+		if tok != "(" {
+			return trees, parseErrorf(p.line, "Expected ( at the start of a tree. Got %q", tok)
+		}
+		t, err := p.tree()
+		if err != nil {
+			return trees, err
+		}
+		trees = append(trees, t)
+	}
+}
+
+// LoadPTB loads a file in the bracketed format of the Penn Treebank, and converts the trees to dependencies. See ConvertTree.
+func LoadPTB(filename string, opts ...treeOpt) ([]SentenceTag, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	trees, err := ReadPTB(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Unable to load %v", filename)
+	}
+	return ConvertTrees(trees, opts...)
+}
+
+// WritePTB writes the trees in the bracketed format of the Penn Treebank, one tree per line.
+func WritePTB(w io.Writer, trees ...*Tree) error {
+	bw := bufio.NewWriter(w)
+	for _, t := range trees {
+		bw.WriteString(t.String())
+		bw.WriteByte('\n')
+	}
+	return bw.Flush()
+}
+
+type ptbReader struct {
+	r    *bufio.Reader
+	line int
+}
+
+// tree reads a tree, after its opening bracket
+func (p *ptbReader) tree() (*Tree, error) {
+	line := p.line
+	t := new(Tree)
+	for first := true; ; first = false {
+		tok, err := p.token()
+		if err == io.EOF {
+			return nil, parseErrorf(line, "Unbalanced brackets")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case tok == ")":
+			if t.IsLeaf() {
+				return nil, parseErrorf(p.line, "Empty constituent %q", t.Label)
+			}
+			return t, nil
+		case tok == "(":
+			c, err := p.tree()
+			if err != nil {
+				return nil, err
+			}
+			t.Children = append(t.Children, c)
+		case first:
+			t.Label = tok
+		default:
+			t.Children = append(t.Children, &Tree{Label: tok})
+		}
+	}
+}
+
+// token returns the next bracket or word
+func (p *ptbReader) token() (string, error) {
+	var buf bytes.Buffer
+	for {
+		r, _, err := p.r.ReadRune()
+		if err != nil {
+			if err == io.EOF && buf.Len() > 0 {
+				return buf.String(), nil
+			}
+			return "", err
+		}
+
+		switch {
+		case r == '(' || r == ')':
+			if buf.Len() > 0 {
+				p.r.UnreadRune()
+				return buf.String(), nil
+			}
+			return string(r), nil
+		case unicode.IsSpace(r):
+			if buf.Len() > 0 {
+				p.r.UnreadRune()
+				return buf.String(), nil
+			}
+			if r == '\n' {
+				p.line++
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
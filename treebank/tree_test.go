@@ -0,0 +1,213 @@
+package treebank
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+const samplePTB = `( (S 
+    (NP-SBJ (DT The) (NN cat) )
+    (VP (MD will) 
+      (VP (VB eat) 
+        (NP (DT the) (NN fish) )
+        (PP-LOC (IN in) 
+          (NP (DT the) (NN kitchen) ))))
+    (. .) ))
+( (S 
+    (NP-SBJ-1 (NNP John) (CC and) (NNP Mary) )
+    (VP (VBD were) 
+      (VP (VBN seen) 
+        (NP (-NONE- *-1) )
+        (PP (IN by) 
+          (NP (NP (NNP Bob) (POS 's) ) (NN dog) ))))
+    (. .) ))
+`
+
+func TestReadPTB(t *testing.T) {
+	assert := assert.New(t)
+	trees, err := ReadPTB(strings.NewReader(samplePTB))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(trees))
+
+	tree := trees[0]
+	assert.Equal("", tree.Label)
+	assert.Equal("(S (NP-SBJ (DT The) (NN cat)) (VP (MD will) (VP (VB eat) (NP (DT the) (NN fish)) (PP-LOC (IN in) (NP (DT the) (NN kitchen))))) (. .))", tree.Children[0].String())
+	assert.Equal([]string{"The", "cat", "will", "eat", "the", "fish", "in", "the", "kitchen", "."}, tree.Words())
+	assert.Equal([]string{"DT", "NN", "MD", "VB", "DT", "NN", "IN", "DT", "NN", "."}, tree.Tags())
+
+	np := trees[1].Children[0].Children[0]
+	assert.Equal("NP", np.Category())
+	assert.Equal([]string{"SBJ"}, np.FunctionTags())
+	assert.True(np.HasFunctionTag("SBJ"))
+	none := &Tree{Label: "-NONE-"}
+	assert.Equal("-NONE-", none.Category())
+	assert.Nil(none.FunctionTags())
+
+	assert.Equal(11, len(trees[1].Words()))
+	assert.Equal(10, len(trees[1].WithoutEmpty().Words()))
+
+	// round trip
+	var buf bytes.Buffer
+	if err = WritePTB(&buf, trees...); err != nil {
+		t.Fatal(err)
+	}
+	again, err := ReadPTB(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(trees, again)
+}
+
+func TestReadPTB_Errors(t *testing.T) {
+	assert := assert.New(t)
+	for _, s := range []string{
+		"(S (NP (NN cat))",
+		"(S (NP (NN cat))))",
+		"(S (NP))",
+		"cat",
+	} {
+		_, err := ReadPTB(strings.NewReader(s))
+		assert.Error(err, s)
+		_, ok := errors.Cause(err).(*ParseError)
+		assert.True(ok, s)
+	}
+
+	_, err := ReadPTB(strings.NewReader("(S (NP (NN cat))\n(VP (VBD sat)\n"))
+	assert.Equal(2, err.(*ParseError).Line)
+
+	_, err = ParseTree(samplePTB)
+	assert.Error(err)
+}
+
+func TestHeadRules(t *testing.T) {
+	assert := assert.New(t)
+	for s, h := range map[string]int{
+		"(NP (DT the) (JJ big) (NN cat))":                      2,
+		"(NP (NP (NNP Bob) (POS 's)) (NN dog))":                1,
+		"(NP (NNS cats) (CC and) (NNS dogs))":                  0,
+		"(VP (TO to) (VP (VB go)))":                            0,
+		"(PP (IN in) (NP (NN kitchen)))":                       0,
+		"(ADVP (RB very) (RB quickly))":                        1,
+		"(FOO (NN a) (NN b))":                                  0,
+		"(S (NP-SBJ (PRP it)) (VP (VBZ works)) (. .))":         1,
+		"(SBAR (IN that) (S (NP (PRP it)) (VP (VBZ is))))":     0,
+		"(NP (NP (DT the) (NN cat)) (PP (IN in) (NP (NN a))))": 0,
+	} {
+		tree, err := ParseTree(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(h, CollinsHeadRules.Head(tree), s)
+	}
+
+	for s, h := range map[string]int{
+		"(VP (TO to) (VP (VB go)))":                        1,
+		"(PP (IN in) (NP (NN kitchen)))":                   1,
+		"(SBAR (IN that) (S (NP (PRP it)) (VP (VBZ is))))": 1,
+		"(NP (NP (NNP Bob) (POS 's)) (NN dog))":            1,
+	} {
+		tree, err := ParseTree(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(h, ContentHeadRules.Head(tree), s)
+	}
+}
+
+func TestConvertTree(t *testing.T) {
+	assert := assert.New(t)
+	trees, err := ReadPTB(strings.NewReader(samplePTB))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st, err := ConvertTree(trees[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("The cat will eat the fish in the kitchen .", st.String())
+	assert.Equal([]lingua.POSTag{lingua.DET, lingua.NOUN, lingua.AUX, lingua.VERB, lingua.DET, lingua.NOUN, lingua.ADP, lingua.DET, lingua.NOUN, lingua.PUNCT}, st.Tags)
+	assert.Equal(lingua.PennTags, st.SetTags()[0].TagSet())
+	assert.Equal([]int{2, 3, 0, 3, 6, 4, 4, 9, 7, 3}, st.Heads)
+	assert.Equal("[Det NSubj Root Dep Det Obj Case Det NMod Punct]", ltosString(st.Labels))
+
+	st, err = ConvertTree(trees[0], WithHeadFinder(ContentHeadRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal([]int{2, 4, 4, 0, 6, 4, 9, 9, 4, 4}, st.Heads)
+	assert.Equal("[Det NSubj Aux Root Det Obj Case Det Obl Punct]", ltosString(st.Labels))
+
+	// empty elements, coordination, passives and possessives
+	st, err = ConvertTree(trees[1], WithHeadFinder(ContentHeadRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("John and Mary were seen by Bob 's dog .", st.String())
+	assert.Equal([]int{5, 1, 1, 5, 0, 9, 9, 7, 5, 5}, st.Heads)
+	assert.Equal("[NSubj Coordination Conj Aux_Pass Root Case NMod_Poss Case Obl Punct]", ltosString(st.Labels))
+
+	// a custom Labeler
+	st, err = ConvertTree(trees[0], WithLabeler(func(HeadFinder, *Tree, int, int) lingua.DependencyType { return lingua.Dep }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(lingua.Root, st.Labels[2])
+	assert.Equal(lingua.Dep, st.Labels[0])
+
+	// the SentenceTags can be written
+	var buf bytes.Buffer
+	if err = WriteConllu(&buf, st); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("1\tThe\t_\tDET\tDT\t_\t2\tdep\t_\t_", strings.Split(buf.String(), "\n")[0])
+
+	// UD v2 relations for negations and temporal NPs
+	tree, _ := ParseTree("(S (NP-SBJ (PRP He)) (VP (VBD did) (RB not) (VP (VB come) (NP-TMP (NN yesterday)))))")
+	st, err = ConvertTree(tree, WithHeadFinder(ContentHeadRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("[NSubj Aux AdvMod Root Obl_TMod]", ltosString(st.Labels))
+
+	tree, _ = ParseTree("(S (NP (-NONE- *)))")
+	_, err = ConvertTree(tree)
+	assert.Error(err)
+	tree, _ = ParseTree("(S (NP (DT the) cat) (VP (VBD sat)))")
+	_, err = ConvertTree(tree)
+	assert.Error(err)
+}
+
+func TestLoadPTB(t *testing.T) {
+	assert := assert.New(t)
+	dir, err := ioutil.TempDir("", "treebank")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "wsj_0001.mrg")
+	if err = ioutil.WriteFile(filename, []byte(samplePTB), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sentences, err := LoadPTB(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(2, len(sentences))
+	assert.Equal(1, sentences[1].Sentence[0].Line)
+}
+
+func ltosString(dts []lingua.DependencyType) string {
+	return "[" + strings.Join(ltos(dts), " ") + "]"
+}
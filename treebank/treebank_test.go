@@ -50,9 +50,12 @@ const sampleConllu = `1	Bagaimana	bagaimana	DET	W--	PronType=Int	2	amod	_	MorphI
 
 func Test_ReadConllu(t *testing.T) {
 	assert := assert.New(t)
-	stu := ReadConllu(strings.NewReader(sampleConllu))
+	stu, err := ReadConllu(strings.NewReader(sampleConllu))
+	if err != nil {
+		t.Fatal(err)
+	}
 	t.Logf("%v", stu)
-	st := ReadConllu(strings.NewReader(sampleConllu))[0]
+	st := stu[0]
 
 	// correctHeads := []int{2, 5, 4, 5, 0, 7, 5, 9, 5, 11, 9, 14, 14, 11, 18, 18, 18, 14, 5}
 	correctHeads := []int{2, 0, 2, 2, 4, 4, 9, 9, 2, 11, 9, 11, 2}
@@ -111,14 +114,14 @@ func Test_ReadConllu(t *testing.T) {
 			"AMod",
 			"Root",
 			"Compound",
-			"NoDepType",
-			"NoDepType",
-			"NoDepType",
-			"NoDepType",
+			"Flat",
+			"Flat",
+			"Flat",
+			"NSubj_Pass",
 			"AdvMod",
 			"ACl",
 			"Case",
-			"NoDepType",
+			"Obl",
 			"Det",
 			"Punct",
 		}
@@ -1,6 +1,10 @@
 package treebank
 
-import "github.com/sapariduo/lingua"
+import (
+	"strings"
+
+	"github.com/sapariduo/lingua"
+)
 
 var alreadyLogged map[string]bool = make(map[string]bool)
 
@@ -20,30 +24,62 @@ func StringToLexType(tag string) lingua.LexemeType {
 	return lexType
 }
 
+// StringToPOSTag returns the POSTag of a universal tag. See lingua.UniversalTags.
 func StringToPOSTag(tag string) (lingua.POSTag, bool) {
-	t, ok := posTagTable[tag]
-
-	return t, ok
+	return stringToPOSTag(lingua.UniversalTags, tag)
 }
 
+// StringToDependencyType returns the DependencyType of a universal relation. See lingua.UniversalRelations.
 func StringToDependencyType(ud string) (lingua.DependencyType, bool) {
-	dt, ok := dependencyTable[ud]
+	return stringToDependencyType(lingua.UniversalRelations, ud)
+}
+
+// POSTagToString returns the name of the POSTag as it is written in a treebank. Unknown POSTags are written as X.
+func POSTagToString(t lingua.POSTag) string { return posTagToString(lingua.UniversalTags, t) }
+
+// DependencyTypeToString returns the name of the DependencyType as it is written in a treebank. Unknown DependencyTypes are written as dep.
+func DependencyTypeToString(dt lingua.DependencyType) string {
+	return dependencyTypeToString(lingua.UniversalRelations, dt)
+}
 
-	return dt, ok
+func stringToPOSTag(ts *lingua.TagSet, tag string) (lingua.POSTag, bool) {
+	t, ok := ts.Tag(tag)
+	return t.POSTag(), ok
 }
 
-func reset() (lingua.LexemeSentence, []lingua.POSTag, []int, []lingua.DependencyType) {
-	s := lingua.NewLexemeSentence()
-	st := make([]lingua.POSTag, 0)
-	sh := make([]int, 0)
-	sdt := make([]lingua.DependencyType, 0)
+func stringToDependencyType(rs *lingua.RelationSet, rel string) (lingua.DependencyType, bool) {
+	r, ok := rs.Relation(rel)
+	return r.DependencyType(), ok
+}
 
-	return s, st, sh, sdt
+// posTagToString returns the name of the first tag of the TagSet with the POSTag. X and sentinels like -ROOT- are written as X.
+func posTagToString(ts *lingua.TagSet, t lingua.POSTag) string {
+	if t == lingua.X {
+		return "X"
+	}
+	if tag, ok := ts.FromPOSTag(t); ok && !strings.HasPrefix(tag.String(), "-") {
+		return tag.String()
+	}
+	return "X"
 }
 
-func finish(s lingua.LexemeSentence, st []lingua.POSTag, sh []int, sdt []lingua.DependencyType, sentences []SentenceTag) []SentenceTag {
-	sentenceTag := SentenceTag{s, st, sh, sdt}
-	sentences = append(sentences, sentenceTag)
+// dependencyTypeToString returns the name of the first relation of the RelationSet with the DependencyType. Sentinels like -NULL- are written as dep.
+func dependencyTypeToString(rs *lingua.RelationSet, dt lingua.DependencyType) string {
+	if rel, ok := rs.FromDependencyType(dt); ok && !strings.HasPrefix(rel.String(), "-") {
+		return rel.String()
+	}
+	return "dep"
+}
 
-	return sentences
+// lexTypeOf returns the LexemeType of a word with the POSTag
+func lexTypeOf(t lingua.POSTag) lingua.LexemeType {
+	switch t {
+	case lingua.NUM:
+		return lingua.Number
+	case lingua.PUNCT:
+		return lingua.Punctuation
+	case lingua.SYM:
+		return lingua.Symbol
+	}
+	return lingua.Word
 }
@@ -0,0 +1,145 @@
+package treebank
+
+import (
+	"fmt"
+
+	"github.com/sapariduo/lingua"
+)
+
+type validator struct {
+	repair bool
+}
+
+type validateOpt func(*validator)
+
+// Repair repairs the trees it can: heads that are out of range, self-loops, cycles, missing or multiple roots and misplaced root labels.
+// The words are attached to the root word, with a Dep label (the first word labelled as the root is kept as the root).
+// Unknown labels become Dep. Relations that don't fit the POS of the word aren't repaired.
+func Repair() validateOpt {
+	return func(v *validator) {
+		v.repair = true
+	}
+}
+
+// Validate checks the trees of the sentences, and returns their Issues, with the index of the sentence in the treebank. See lingua.ValidateTree.
+//
+// These are the options:
+//		Repair
+func Validate(sentences []SentenceTag, opts ...validateOpt) []lingua.Issue {
+	var issues []lingua.Issue
+	for i := range sentences {
+		for _, issue := range sentences[i].Validate(opts...) {
+			issue.Sentence = i
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// Validate checks the tree of the sentence, and returns its Issues. See lingua.ValidateTree.
+// Unknown labels are the DEPREL that aren't in the RelationSet of the sentence. A sentence without a tree (see HasTree) has no Issues.
+//
+// With the Repair option, the Heads and Labels are repaired in place, and the Issues that were repaired are marked as such.
+func (s *SentenceTag) Validate(opts ...validateOpt) []lingua.Issue {
+	v := new(validator)
+	for _, opt := range opts {
+		opt(v)
+	}
+	if !s.HasTree() {
+		return nil
+	}
+
+	issues := lingua.ValidateTree(s.Heads, s.Labels, s.Tags)
+	for i, issue := range issues {
+		if w := issue.Word - 1; issue.Type == lingua.UnknownLabel && w < len(s.columns) {
+			issues[i].Msg = fmt.Sprintf("Unknown label %q", s.columns[w][7])
+		}
+	}
+	if !v.repair || len(issues) == 0 {
+		return issues
+	}
+
+	repairTree(s.Heads, s.Labels)
+	left := make(map[[2]int]bool)
+	for _, issue := range lingua.ValidateTree(s.Heads, s.Labels, s.Tags) {
+		left[[2]int{int(issue.Type), issue.Word}] = true
+	}
+	for i, issue := range issues {
+		issues[i].Repaired = !left[[2]int{int(issue.Type), issue.Word}]
+	}
+	return issues
+}
+
+// repairTree makes a tree of the heads (see Repair)
+func repairTree(heads []int, labels []lingua.DependencyType) {
+	n := len(heads)
+	if n == 0 {
+		return
+	}
+	label := func(i int) lingua.DependencyType {
+		if i < len(labels) {
+			return labels[i]
+		}
+		return lingua.Dep
+	}
+	setLabel := func(i int, l lingua.DependencyType) {
+		if i < len(labels) {
+			labels[i] = l
+		}
+	}
+
+	for i, h := range heads {
+		if h < 0 || h > n || h == i+1 {
+			heads[i] = -1
+		}
+	}
+
+	// the root is the first word labelled as the root, or else the first word that depends on the root, or else the first word without a head
+	root := -1
+	for _, ok := range []func(int) bool{
+		func(i int) bool { return heads[i] == 0 && label(i) == lingua.Root },
+		func(i int) bool { return heads[i] == 0 },
+		func(i int) bool { return heads[i] < 0 },
+	} {
+		for i := range heads {
+			if ok(i) {
+				root = i
+				break
+			}
+		}
+		if root >= 0 {
+			break
+		}
+	}
+	if root < 0 {
+		root = 0
+	}
+	heads[root] = 0
+	setLabel(root, lingua.Root)
+
+	for i, h := range heads {
+		if i == root {
+			continue
+		}
+		if h <= 0 {
+			heads[i] = root + 1
+		}
+		if l := label(i); l == lingua.Root || l == lingua.NoDepType || l >= lingua.MAXDEPTYPE {
+			setLabel(i, lingua.Dep)
+		}
+	}
+
+	// a word that doesn't reach the root in n steps leads to a cycle. The word it reaches is in the cycle, and is attached to the root word
+	for i := range heads {
+		for {
+			k := i + 1
+			for steps := 0; k != 0 && steps < n; steps++ {
+				k = heads[k-1]
+			}
+			if k == 0 {
+				break
+			}
+			heads[k-1] = root + 1
+		}
+	}
+}
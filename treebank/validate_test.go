@@ -0,0 +1,70 @@
+package treebank
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+// brokenConllu has a cycle and a second root in its first sentence, and an unknown label and a head out of range in its second
+const brokenConllu = `1	Ani	Ani	PROPN	_	_	3	nsubj	_	_
+2	makan	makan	VERB	_	_	0	root	_	_
+3	nasi	nasi	NOUN	_	_	1	obj	_	_
+4	tadi	tadi	ADV	_	_	0	advmod	_	_
+
+1	Ani	Ani	PROPN	_	_	2	foo	_	_
+2	makan	makan	VERB	_	_	0	root	_	_
+3	.	.	PUNCT	_	_	2	punct	_	_`
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(brokenConllu))
+	if !assert.NoError(err) {
+		return
+	}
+	sentences[1].Heads[2] = 9
+
+	issues := Validate(sentences)
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Error())
+		assert.False(issue.Repaired)
+	}
+	assert.Equal([]string{
+		"Sentence 1: Words [2 4] depend on the root",
+		"Sentence 1, word 1: The word is in a cycle",
+		"Sentence 1, word 3: The word is in a cycle",
+		"Sentence 1, word 4: AdvMod with head 0",
+		"Sentence 2, word 3: Head 9 is out of range",
+		"Sentence 2, word 1: Unknown label \"foo\"",
+	}, got)
+
+	issues = Validate(sentences, Repair())
+	for _, issue := range issues {
+		assert.True(issue.Repaired, "%v", issue)
+	}
+	assert.Empty(Validate(sentences))
+	assert.Equal([]int{2, 0, 1, 2}, sentences[0].Heads)
+	assert.Equal([]lingua.DependencyType{lingua.NSubj, lingua.Root, lingua.Obj, lingua.AdvMod}, sentences[0].Labels)
+	assert.Equal([]int{2, 0, 2}, sentences[1].Heads)
+	assert.Equal(lingua.Dep, sentences[1].Labels[0])
+}
+
+func TestValidate_NoRoot(t *testing.T) {
+	assert := assert.New(t)
+	st := SentenceTag{
+		Heads:  []int{2, 3, 1},
+		Labels: []lingua.DependencyType{lingua.Det, lingua.Det, lingua.Det},
+		Tags:   []lingua.POSTag{lingua.DET, lingua.NOUN, lingua.VERB},
+	}
+	issues := st.Validate(Repair())
+	assert.Equal(lingua.NoRoot, issues[0].Type)
+	assert.True(issues[0].Repaired)
+	assert.Equal([]int{0, 3, 1}, st.Heads)
+	assert.Equal(lingua.Root, st.Labels[0])
+
+	// det on a NOUN and a VERB can't be repaired
+	assert.Len(st.Validate(), 2)
+}
@@ -0,0 +1,203 @@
+package treebank
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sapariduo/lingua"
+)
+
+// WriteConllu writes the sentences in a CONLLU format.
+//
+// The columns that were read by ReadConllu are written as they were, unless the SentenceTag has been changed since
+// (e.g. the Heads and Labels have been replaced by the output of a parser), so reading a treebank and writing it reproduces the file.
+// The FORM column is escaped with lingua.EscapeSpecials, unless the form was read that way.
+func WriteConllu(w io.Writer, sentences ...SentenceTag) error {
+	bw := bufio.NewWriter(w)
+	for _, s := range sentences {
+		for _, c := range s.Comments {
+			fmt.Fprintln(bw, c)
+		}
+
+		spaces := hasSpaceAfter(s.Sentence)
+		mw, en := 0, 0
+		for _, n := range s.EmptyNodes {
+			if n.After > 0 {
+				break
+			}
+			writeEmptyNode(bw, n)
+			en++
+		}
+
+		for i, lex := range s.Sentence {
+			id := i + 1
+			for ; mw < len(s.MultiWords) && s.MultiWords[mw].Start == id; mw++ {
+				m := s.MultiWords[mw]
+				writeColumns(bw, fmt.Sprintf("%d-%d", m.Start, m.End), lingua.EscapeSpecials(m.Form), "_", "_", "_", "_", "_", "_", "_", or(m.Misc))
+			}
+
+			cols := s.wordColumns(i, spaces)
+			cols[0] = strconv.Itoa(id)
+			cols[1] = escapeForm(lex.Value, cols[1])
+			writeColumns(bw, cols...)
+
+			for ; en < len(s.EmptyNodes) && s.EmptyNodes[en].After == id; en++ {
+				writeEmptyNode(bw, s.EmptyNodes[en])
+			}
+		}
+		fmt.Fprintln(bw)
+	}
+	return bw.Flush()
+}
+
+// WriteAnnotatedConllu writes the sentences in a CONLLU format. Each sentence gets a sent_id comment (its position, starting from 1)
+// and, if the whitespace of the sentence is known, a text comment and the SpaceAfter=No of the MISC column.
+// Annotations without a head are written with "_" for their HEAD and DEPREL.
+func WriteAnnotatedConllu(w io.Writer, sentences ...lingua.AnnotatedSentence) error {
+	bw := bufio.NewWriter(w)
+	for i, as := range sentences {
+		writeAnnotated(bw, i+1, as, nil)
+	}
+	return bw.Flush()
+}
+
+// WriteEnhancedConllu is like WriteAnnotatedConllu, but the DEPS column and the empty nodes are written from the graphs.
+func WriteEnhancedConllu(w io.Writer, graphs ...*lingua.EnhancedGraph) error {
+	bw := bufio.NewWriter(w)
+	for i, g := range graphs {
+		writeAnnotated(bw, i+1, g.AnnotatedSentence, g)
+	}
+	return bw.Flush()
+}
+
+// writeAnnotated writes a sentence, and if the graph is not nil, its enhanced dependencies
+func writeAnnotated(w io.Writer, id int, as lingua.AnnotatedSentence, g *lingua.EnhancedGraph) {
+	words := as
+	if len(as) > 0 && as[0] == lingua.RootAnnotation() {
+		words = as[1:]
+	}
+	lexemes := make(lingua.LexemeSentence, len(words))
+	for j, a := range words {
+		lexemes[j] = a.Lexeme
+	}
+	spaces := hasSpaceAfter(lexemes)
+
+	fmt.Fprintf(w, "# sent_id = %d\n", id)
+	if spaces {
+		fmt.Fprintf(w, "# text = %s\n", strings.TrimSpace(lexemes.Detokenize()))
+	}
+
+	var empty []lingua.NodeID
+	if g != nil {
+		empty = g.EmptyNodes()
+	}
+	// the empty nodes that follow a word (or the root)
+	writeEmpty := func(after int) {
+		for ; len(empty) > 0 && empty[0].Word == after; empty = empty[1:] {
+			n := emptyNodeOf(lingua.UniversalTags, empty[0], g.Node(empty[0]))
+			n.Deps = g.Deps(empty[0])
+			writeEmptyNode(w, n)
+		}
+	}
+
+	writeEmpty(0)
+	for j, a := range words {
+		head, label := "_", "_"
+		if a.Head != nil {
+			head = strconv.Itoa(a.HeadID())
+			if a.Head == lingua.RootAnnotation() {
+				head = "0"
+			}
+			label = DependencyTypeToString(a.DependencyType)
+		}
+		deps := "_"
+		if g != nil {
+			deps = or(g.Deps(lingua.WordNode(j + 1)))
+		}
+		misc := "_"
+		if spaces && a.SpaceAfter == "" && j < len(words)-1 {
+			misc = "SpaceAfter=No"
+		}
+		writeColumns(w, strconv.Itoa(j+1), lingua.EscapeSpecials(a.Value), or(a.Lemma), POSTagToString(a.POSTag), "_", or(a.Features.String()), head, label, deps, misc)
+		writeEmpty(j + 1)
+	}
+	fmt.Fprintln(w)
+}
+
+// wordColumns returns the columns of the ith word. The columns that were read are used if they agree with the SentenceTag.
+func (s SentenceTag) wordColumns(i int, spaces bool) []string {
+	cols := []string{"", "", "_", "_", "_", "_", "_", "_", "_", "_"}
+	read := i < len(s.columns)
+	if read {
+		copy(cols, s.columns[i])
+	}
+	for col, values := range map[int][]string{2: s.Lemmas, 3: s.UPOS, 4: s.XPOS, 9: s.Misc} {
+		if i < len(values) {
+			cols[col] = or(values[i])
+		}
+	}
+
+	// a column is kept if it reads to the same value. This keeps the names that aren't in the tables (e.g. obl:dengan)
+	ts, rs := s.tagSet(), s.relationSet()
+	tagCol := tagColumn(ts)
+	if t, _ := stringToPOSTag(ts, cols[tagCol]); t != s.Tags[i] || (!read && cols[tagCol] == "_") {
+		cols[tagCol] = posTagToString(ts, s.Tags[i])
+	}
+	if dt, _ := stringToDependencyType(rs, cols[7]); !read || dt != s.Labels[i] {
+		cols[7] = dependencyTypeToString(rs, s.Labels[i])
+	}
+	if i < len(s.Features) {
+		if fs, err := lingua.ParseFeatures(cols[5]); !read || err != nil || !fs.Equal(s.Features[i]) {
+			cols[5] = or(s.Features[i].String())
+		}
+	}
+	cols[6] = "_"
+	if s.Heads[i] >= 0 {
+		cols[6] = strconv.Itoa(s.Heads[i])
+	}
+	if i < len(s.Deps) {
+		cols[8] = or(s.Deps[i])
+	}
+
+	if i >= len(s.Misc) && spaces && s.Sentence[i].SpaceAfter == "" && i < len(s.Sentence)-1 {
+		cols[9] = "SpaceAfter=No"
+	}
+	return cols
+}
+
+// escapeForm escapes the form, unless it was read as it is
+func escapeForm(form, read string) string {
+	if read != "" && lingua.UnescapeSpecials(read) == form {
+		return read
+	}
+	return lingua.EscapeSpecials(form)
+}
+
+// hasSpaceAfter checks if the whitespace of the lexemes is known: if none of the lexemes has a SpaceAfter, it isn't.
+func hasSpaceAfter(ls lingua.LexemeSentence) bool {
+	for _, lex := range ls {
+		if lex.SpaceAfter != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeEmptyNode(w io.Writer, n EmptyNode) {
+	writeColumns(w, n.ID(), lingua.EscapeSpecials(n.Form), or(n.Lemma), or(n.UPOS), or(n.XPOS), or(n.Feats), "_", "_", or(n.Deps), or(n.Misc))
+}
+
+func writeColumns(w io.Writer, cols ...string) {
+	fmt.Fprintln(w, strings.Join(cols, "\t"))
+}
+
+// or returns "_" for empty strings
+func or(s string) string {
+	if s == "" {
+		return "_"
+	}
+	return s
+}
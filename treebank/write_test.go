@@ -0,0 +1,106 @@
+package treebank
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sapariduo/lingua"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteConllu_RoundTrip(t *testing.T) {
+	for _, conllu := range []string{sampleConllu, udv2Conllu + "\n\n"} {
+		sentences, err := ReadConllu(strings.NewReader(conllu))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		if err = WriteConllu(&buf, sentences...); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != conllu {
+			t.Errorf("Expected a byte for byte round trip.\nExpected:\n%s\nGot:\n%s", conllu, buf.String())
+		}
+	}
+}
+
+func TestWriteConllu_Predicted(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := ReadConllu(strings.NewReader(udv2Conllu))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replace the parse of the first sentence
+	s := sentences[0]
+	s.Heads = []int{3, 1, 0, 3, 6, 3, 6, 6}
+	s.Labels[7] = lingua.Dep
+	s.Features[3] = s.Features[3].With("Person", "1")
+	s.Features[4] = s.Features[4].With("AdpType", "Prep")
+	s.XPOS[4] = "R-P"
+	s.Lemmas[0] = "Gedung"
+	s.Tags[1] = lingua.PRON
+
+	var buf bytes.Buffer
+	if err = WriteConllu(&buf, s); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal("3-4\tberdirinya\t_\t_\t_\t_\t_\t_\t_\t_", lines[5])
+	assert.Equal("4\tnya\tdia\tPRON\tPS3\tNumber=Sing|Person=1|PronType=Prs\t3\tnmod\t_\t_", lines[7])
+	assert.Equal("1\tGedung\tGedung\tNOUN\tNSD\t_\t3\tnsubj\t_\t_", lines[3])
+	assert.Equal("2\titu\titu\tPRON\tB--\t_\t1\tdet\t_\t_", lines[4], "Tags win over the UPOS that was read")
+	assert.Equal("5\tdi\tdi\tADP\tR-P\tAdpType=Prep\t6\tcase\t_\t_", lines[8])
+	assert.Equal("8\t.\t.\tPUNCT\tZ--\t_\t6\tdep\t_\t_", lines[11])
+	assert.Equal("6\tpusat\tpusat\tNOUN\tNSD\t_\t3\tobl\t_\t_", lines[9], "unknown relations that haven't changed are kept")
+
+	// a SentenceTag that wasn't read
+	st := SentenceTag{
+		Sentence: lingua.LexemeSentence{lingua.MakeLexeme("(", lingua.Punctuation), lingua.MakeLexeme("Halo", lingua.Word)},
+		Tags:     []lingua.POSTag{lingua.PUNCT, lingua.X},
+		Heads:    []int{2, 0},
+		Labels:   []lingua.DependencyType{lingua.Punct, lingua.Root},
+	}
+	buf.Reset()
+	if err = WriteConllu(&buf, st); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal("1\t-LRB-\t_\tPUNCT\t_\t_\t2\tpunct\t_\t_\n2\tHalo\t_\tX\t_\t_\t0\troot\t_\t_\n\n", buf.String())
+}
+
+func TestWriteAnnotatedConllu(t *testing.T) {
+	assert := assert.New(t)
+	sentences, err := lingua.NewPipeline().ProcessString("Halo, dunia! Apa kabar?")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, as := range sentences {
+		for _, a := range as[1:] {
+			a.SetHead(lingua.RootAnnotation())
+			a.DependencyType = lingua.Root
+		}
+	}
+	sentences[0][1].Features, _ = lingua.ParseFeatures("Polite=Infm")
+
+	var buf bytes.Buffer
+	if err = WriteAnnotatedConllu(&buf, sentences...); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal("# sent_id = 1", lines[0])
+	assert.Equal("# text = Halo, dunia!", lines[1])
+	assert.Equal("1\tHalo\t_\tX\t_\tPolite=Infm\t0\troot\t_\tSpaceAfter=No", lines[2])
+	assert.Equal("4\t!\t_\tPUNCT\t_\t_\t0\troot\t_\t_", lines[5])
+
+	read, err := ReadConllu(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if assert.Equal(2, len(read)) {
+		assert.Equal("Halo, dunia!", read[0].Text())
+		assert.Equal("Halo, dunia! ", read[0].Sentence.Detokenize())
+		assert.Equal("Apa kabar?", read[1].Text())
+	}
+}
@@ -0,0 +1,105 @@
+package lingua
+
+// v1ToV2 are the UD v1 relations that are simply renamed in UD v2.
+var v1ToV2 = map[DependencyType]DependencyType{
+	DObj:      Obj,
+	NSubjPass: NSubj_Pass,
+	CSubjPass: CSubj_Pass,
+	AuxPass:   Aux_Pass,
+	MWE:       Fixed,
+	Name:      Flat_Name,
+	Foreign:   Flat_Foreign,
+	Remnant:   Orphan,
+}
+
+// v2ToV1 are the UD v2 relations and their closest UD v1 relation.
+var v2ToV1 = map[DependencyType]DependencyType{
+	Obj:            DObj,
+	Obl:            NMod,
+	Obl_TMod:       NMod_TMod,
+	Obl_NPMod:      NMod_NPMod,
+	Obl_Agent:      NMod,
+	Obl_Arg:        NMod,
+	Clf:            Dep,
+	Fixed:          MWE,
+	Flat:           Name,
+	Flat_Name:      Name,
+	Flat_Foreign:   Foreign,
+	Orphan:         Remnant,
+	NSubj_Pass:     NSubjPass,
+	CSubj_Pass:     CSubjPass,
+	Aux_Pass:       AuxPass,
+	Det_Poss:       NMod_Poss,
+	Compound_Redup: Compound,
+	Compound_LVC:   Compound,
+	Compound_SVC:   Compound,
+	AdvMod_Emph:    AdvMod,
+	Expl_Pass:      Expl,
+	Expl_Impers:    Expl,
+}
+
+// ToV2 converts a UD v1 relation to UD v2. head and dependent are the POSTags of the head and the dependent of the relation, which
+// decide the relations that were split in UD v2:
+//		nmod (and nmod:tmod, nmod:npmod) of a predicate (VERB, ADJ, ADV, AUX) becomes obl (and obl:tmod, obl:npmod)
+//		neg becomes det if the dependent is a determiner, advmod otherwise
+// Relations that are the same in both versions are returned as they are.
+func ToV2(dt DependencyType, head, dependent POSTag) DependencyType {
+	if v2, ok := v1ToV2[dt]; ok {
+		return v2
+	}
+
+	switch dt {
+	case Neg:
+		if dependent == DET {
+			return Det
+		}
+		return AdvMod
+	case NMod, NMod_TMod, NMod_NPMod:
+		switch head {
+		case VERB, ADJ, ADV, AUX:
+		default:
+			return dt
+		}
+		switch dt {
+		case NMod_TMod:
+			return Obl_TMod
+		case NMod_NPMod:
+			return Obl_NPMod
+		}
+		return Obl
+	}
+	return dt
+}
+
+// ToV1 converts a UD v2 relation to the closest UD v1 relation. Relations that UD v1 doesn't have (e.g. clf) become dep, and
+// subtypes that UD v1 doesn't have (e.g. compound:redup) become their relation without the subtype.
+func ToV1(dt DependencyType) DependencyType {
+	if v1, ok := v2ToV1[dt]; ok {
+		return v1
+	}
+	return dt
+}
+
+// ConvertToV2 converts the relations of the sentence from UD v1 to UD v2, in place. See ToV2.
+func (as AnnotatedSentence) ConvertToV2() {
+	for _, a := range as {
+		if a == rootAnnotation {
+			continue
+		}
+		head := X
+		if a.Head != nil {
+			head = a.Head.POSTag
+		}
+		a.DependencyType = ToV2(a.DependencyType, head, a.POSTag)
+	}
+}
+
+// ConvertToV1 converts the relations of the sentence from UD v2 to UD v1, in place. See ToV1.
+func (as AnnotatedSentence) ConvertToV1() {
+	for _, a := range as {
+		if a == rootAnnotation {
+			continue
+		}
+		a.DependencyType = ToV1(a.DependencyType)
+	}
+}
@@ -0,0 +1,83 @@
+package lingua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToV2(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(Obj, ToV2(DObj, VERB, NOUN))
+	assert.Equal(NSubj_Pass, ToV2(NSubjPass, VERB, PRON))
+	assert.Equal(Fixed, ToV2(MWE, ADP, ADP))
+	assert.Equal(Flat_Name, ToV2(Name, PROPN, PROPN))
+	assert.Equal(Orphan, ToV2(Remnant, VERB, NOUN))
+
+	// split relations
+	assert.Equal(Obl, ToV2(NMod, VERB, NOUN))
+	assert.Equal(Obl_TMod, ToV2(NMod_TMod, ADJ, NOUN))
+	assert.Equal(NMod, ToV2(NMod, NOUN, NOUN))
+	assert.Equal(NMod_TMod, ToV2(NMod_TMod, NOUN, NOUN))
+	assert.Equal(Det, ToV2(Neg, NOUN, DET))
+	assert.Equal(AdvMod, ToV2(Neg, VERB, PART))
+
+	// unchanged
+	assert.Equal(AMod, ToV2(AMod, NOUN, ADJ))
+	assert.Equal(Obl, ToV2(Obl, VERB, NOUN))
+}
+
+func TestToV1(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(DObj, ToV1(Obj))
+	assert.Equal(NMod, ToV1(Obl))
+	assert.Equal(NMod_TMod, ToV1(Obl_TMod))
+	assert.Equal(Name, ToV1(Flat))
+	assert.Equal(Compound, ToV1(Compound_Redup))
+	assert.Equal(Dep, ToV1(Clf))
+	assert.Equal(AMod, ToV1(AMod))
+
+	// every v1 relation survives the round trip, except the relations that v2 merged
+	for dt := NoDepType; dt < Obj; dt++ {
+		if dt == Neg {
+			continue
+		}
+		assert.Equal(dt, ToV1(ToV2(dt, VERB, NOUN)), "%v", dt)
+	}
+}
+
+func TestAnnotatedSentence_ConvertToV2(t *testing.T) {
+	assert := assert.New(t)
+
+	verb := &Annotation{POSTag: VERB, DependencyType: Root, Head: rootAnnotation}
+	obj := &Annotation{POSTag: NOUN, DependencyType: DObj, Head: verb}
+	obl := &Annotation{POSTag: NOUN, DependencyType: NMod, Head: verb}
+	nmod := &Annotation{POSTag: NOUN, DependencyType: NMod, Head: obj}
+	as := AnnotatedSentence{rootAnnotation, verb, obj, obl, nmod}
+
+	as.ConvertToV2()
+	assert.Equal([]DependencyType{Root, Root, Obj, Obl, NMod}, as.Labels())
+	as.ConvertToV1()
+	assert.Equal([]DependencyType{Root, Root, DObj, NMod, NMod}, as.Labels())
+}
+
+func TestUniversalRelations_V2(t *testing.T) {
+	assert := assert.New(t)
+
+	for name, dt := range map[string]DependencyType{
+		"obj":            Obj,
+		"nsubj:pass":     NSubj_Pass,
+		"compound:redup": Compound_Redup,
+		"flat":           Flat,
+		"obl:dengan":     Obl, // language specific subtypes are read as their relation
+		"nmod:lainnya":   NMod,
+	} {
+		rel, ok := UniversalRelations.Relation(name)
+		assert.True(ok, name)
+		assert.Equal(dt, rel.DependencyType(), name)
+	}
+	_, ok := UniversalRelations.Relation("foo:bar")
+	assert.False(ok)
+}
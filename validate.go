@@ -0,0 +1,147 @@
+package lingua
+
+import "fmt"
+
+// IssueType is the kind of problem found in a dependency tree by Validate
+type IssueType byte
+
+const (
+	HeadOutOfRange   IssueType = iota // the head isn't a word of the sentence, nor the root
+	SelfLoop                          // the word is its own head
+	Cycle                             // the word is in a cycle, so it doesn't depend on the root
+	NoRoot                            // no word depends on the root
+	MultipleRoots                     // several words depend on the root
+	UnknownLabel                      // the label isn't a known relation
+	LabelPOSMismatch                  // the relation doesn't fit the POSTag of the word, e.g. det on a VERB (see RelationPOS)
+	MisplacedRoot                     // the word depends on the root without the root label, or has the root label without depending on the root
+)
+
+var issueTypeNames = [...]string{"head out of range", "self-loop", "cycle", "no root", "multiple roots", "unknown label", "label doesn't fit the POS", "misplaced root"}
+
+func (t IssueType) String() string {
+	if int(t) < len(issueTypeNames) {
+		return issueTypeNames[t]
+	}
+	return fmt.Sprintf("IssueType(%d)", t)
+}
+
+// Issue is a problem found in a dependency tree.
+type Issue struct {
+	Type     IssueType
+	Sentence int    // the index of the sentence in the treebank, when a treebank is validated
+	Word     int    // the ID of the word (starting from 1), or 0 for the whole sentence
+	Msg      string // what is wrong
+	Repaired bool   // whether the problem has been repaired, when it is validated leniently
+}
+
+func (i Issue) Error() string {
+	if i.Word == 0 {
+		return fmt.Sprintf("Sentence %d: %s", i.Sentence+1, i.Msg)
+	}
+	return fmt.Sprintf("Sentence %d, word %d: %s", i.Sentence+1, i.Word, i.Msg)
+}
+
+// RelationPOS are the POSTags that the dependents of a DependencyType may have. Relations that aren't in it may have any POSTag.
+// Subtypes have the POSTags of their types (e.g. det:poss is det), unless they are in it themselves.
+var RelationPOS = map[DependencyType][]POSTag{
+	Det:      {DET, PRON},
+	NumMod:   {NUM},
+	Aux:      {AUX},
+	AuxPass:  {AUX},
+	Aux_Pass: {AUX},
+	Cop:      {AUX, PRON, DET},
+	Punct:    {PUNCT, SYM},
+}
+
+// relationTypes are the types of the subtypes, for RelationPOS
+var relationTypes = map[DependencyType]DependencyType{
+	Det_PreDet: Det,
+	Det_Poss:   Det,
+}
+
+// ValidateTree checks a dependency tree, and returns its Issues. The heads are the IDs of the heads of the words (starting from 1), with 0 for the root.
+// The labels and the tags may be nil, and are then not checked. Words with an X tag aren't checked against RelationPOS.
+func ValidateTree(heads []int, labels []DependencyType, tags []POSTag) []Issue {
+	var issues []Issue
+	add := func(t IssueType, word int, format string, args ...interface{}) {
+		issues = append(issues, Issue{Type: t, Word: word, Msg: fmt.Sprintf(format, args...)})
+	}
+
+	n := len(heads)
+	var roots []int
+	for i, h := range heads {
+		id := i + 1
+		switch {
+		case h < 0 || h > n:
+			add(HeadOutOfRange, id, "Head %d is out of range", h)
+		case h == id:
+			add(SelfLoop, id, "The word is its own head")
+		case h == 0:
+			roots = append(roots, id)
+		}
+	}
+	switch {
+	case len(roots) == 0 && n > 0:
+		add(NoRoot, 0, "No word depends on the root")
+	case len(roots) > 1:
+		add(MultipleRoots, 0, "Words %v depend on the root", roots)
+	}
+
+	// a word is in a cycle if it reaches itself from its head
+	for i := range heads {
+		id := i + 1
+		k := heads[i]
+		for steps := 0; k > 0 && k <= n && k != id && steps < n; steps++ {
+			k = heads[k-1]
+		}
+		if k == id && heads[i] != id {
+			add(Cycle, id, "The word is in a cycle")
+		}
+	}
+
+	for i, l := range labels {
+		id := i + 1
+		if l == NoDepType || l >= MAXDEPTYPE {
+			add(UnknownLabel, id, "Unknown label")
+			continue
+		}
+		if i < len(heads) && ((heads[i] == 0) != (l == Root)) {
+			add(MisplacedRoot, id, "%v with head %d", l, heads[i])
+			continue
+		}
+		if i >= len(tags) || tags[i] == X {
+			continue
+		}
+		allowed, ok := RelationPOS[l]
+		if t, sub := relationTypes[l]; !ok && sub {
+			allowed, ok = RelationPOS[t]
+		}
+		if ok && !InPOSTags(tags[i], allowed) {
+			add(LabelPOSMismatch, id, "%v on a %v", l, tags[i])
+		}
+	}
+	return issues
+}
+
+// Validate checks the dependency tree, and returns its Issues. See ValidateTree.
+func (d *Dependency) Validate() []Issue {
+	return d.AnnotatedSentence.Validate()
+}
+
+// Validate checks the dependency tree of the sentence, and returns its Issues. See ValidateTree.
+// The sentence starts with the root annotation, as in a *Dependency. A word without a head is out of range.
+func (as AnnotatedSentence) Validate() []Issue {
+	if len(as) == 0 {
+		return nil
+	}
+	words := as[1:]
+	heads := make([]int, len(words))
+	labels := make([]DependencyType, len(words))
+	tags := make([]POSTag, len(words))
+	for i, a := range words {
+		heads[i] = a.HeadID()
+		labels[i] = a.DependencyType
+		tags[i] = a.POSTag
+	}
+	return ValidateTree(heads, labels, tags)
+}
@@ -0,0 +1,69 @@
+package lingua
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func issueTypes(issues []Issue) []IssueType {
+	var retVal []IssueType
+	for _, i := range issues {
+		retVal = append(retVal, i.Type)
+	}
+	return retVal
+}
+
+func TestValidateTree(t *testing.T) {
+	assert := assert.New(t)
+
+	var validateTreeTests = []struct {
+		name   string
+		heads  []int
+		labels []DependencyType
+		tags   []POSTag
+		issues []IssueType
+		words  []int
+	}{
+		{"valid", []int{2, 0, 2}, []DependencyType{NSubj, Root, Obj}, []POSTag{PRON, VERB, NOUN}, nil, nil},
+		{"head out of range", []int{2, 0, 4}, nil, nil, []IssueType{HeadOutOfRange}, []int{3}},
+		{"self-loop", []int{2, 0, 3}, nil, nil, []IssueType{SelfLoop}, []int{3}},
+		{"no root", []int{2, 3, 1}, nil, nil, []IssueType{NoRoot, Cycle, Cycle, Cycle}, []int{0, 1, 2, 3}},
+		{"multiple roots", []int{0, 0, 2}, nil, nil, []IssueType{MultipleRoots}, []int{0}},
+		{"cycle", []int{0, 3, 2}, nil, nil, []IssueType{Cycle, Cycle}, []int{2, 3}},
+		{"unknown label", []int{2, 0}, []DependencyType{NoDepType, Root}, nil, []IssueType{UnknownLabel}, []int{1}},
+		{"misplaced root", []int{2, 0}, []DependencyType{Root, Punct}, nil, []IssueType{MisplacedRoot, MisplacedRoot}, []int{1, 2}},
+		{"det on a verb", []int{2, 0, 2}, []DependencyType{Det, Root, Det_Poss}, []POSTag{VERB, NOUN, PRON}, []IssueType{LabelPOSMismatch}, []int{1}},
+		{"X isn't checked", []int{2, 0}, []DependencyType{NumMod, Root}, []POSTag{X, NOUN}, nil, nil},
+	}
+
+	for _, tt := range validateTreeTests {
+		issues := ValidateTree(tt.heads, tt.labels, tt.tags)
+		assert.Equal(tt.issues, issueTypes(issues), tt.name)
+		for i, issue := range issues {
+			assert.Equal(tt.words[i], issue.Word, tt.name)
+		}
+	}
+
+	issue := Issue{Type: Cycle, Sentence: 2, Word: 4, Msg: "The word is in a cycle"}
+	assert.Equal("Sentence 3, word 4: The word is in a cycle", issue.Error())
+	assert.Equal("cycle", Cycle.String())
+}
+
+func TestAnnotatedSentence_Validate(t *testing.T) {
+	assert := assert.New(t)
+
+	verb := &Annotation{POSTag: VERB, DependencyType: Root, Head: rootAnnotation}
+	det := &Annotation{POSTag: VERB, DependencyType: Det, Head: verb}
+	other := &Annotation{POSTag: NOUN, DependencyType: Root, Head: rootAnnotation}
+	as := AnnotatedSentence{rootAnnotation, verb, det, other}
+	as.SetID()
+
+	d := NewDependency(FromAnnotatedSentence(as))
+	assert.Equal([]IssueType{MultipleRoots, LabelPOSMismatch}, issueTypes(d.Validate()))
+	assert.False(as.IsValid())
+
+	other.Head, other.DependencyType = verb, Obj
+	assert.Equal([]IssueType{LabelPOSMismatch}, issueTypes(as.Validate()))
+	assert.True(as.IsValid())
+}
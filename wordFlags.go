@@ -24,12 +24,13 @@ const (
 	LikeEmail
 	IsStopWord
 	IsOOV // for ner
+	IsReduplicated
 
 	MAXFLAG
 )
 
 func (f WordFlag) String() string {
-	return fmt.Sprintf("%014b", f)
+	return fmt.Sprintf("%015b", f)
 }
 
 func (l Lexeme) Flags() WordFlag {
@@ -69,7 +70,11 @@ func (l Lexeme) Flags() WordFlag {
 		wf |= (1 << LikeURL)
 	}
 
-	if _, ok := NumberWords[strings.ToLower(s)]; ok {
+	if emailRegexp.MatchString(s) {
+		wf |= (1 << LikeEmail)
+	}
+
+	if _, ok := NumberWords[strings.ToLower(s)]; ok || l.LexemeType == Number {
 		wf |= (1 << LikeNum)
 	}
 
@@ -77,6 +82,10 @@ func (l Lexeme) Flags() WordFlag {
 		wf |= (1 << IsStopWord)
 	}
 
+	if _, r := l.Reduplication(); r != NotReduplicated {
+		wf |= (1 << IsReduplicated)
+	}
+
 	if len(s) > 0 {
 		if (unicode.IsUpper(rune(s[0])) || unicode.IsTitle(rune(s[0]))) && StringIs(s[1:], unicode.IsLower) {
 			wf |= (1 << IsTitle)